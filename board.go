@@ -0,0 +1,263 @@
+package wordfeud
+
+import "strings"
+
+// BoardSize is the number of rows and columns on a Wordfeud board.
+const BoardSize = 15
+
+// Center is the position of the center square, which must be covered by the first move of a game.
+const Center = BoardSize / 2
+
+// NormalGrid is the layout of the premium squares of a BoardNormal board.
+var NormalGrid = func() Grid {
+	const (
+		__ = SquareNormal
+		DL = SquareDL
+		TL = SquareTL
+		DW = SquareDW
+		TW = SquareTW
+	)
+	return Grid{
+		{TL, __, __, __, TW, __, __, DL, __, __, TW, __, __, __, TL},
+		{__, DL, __, __, __, TL, __, __, __, TL, __, __, __, DL, __},
+		{__, __, DW, __, __, __, DL, __, DL, __, __, __, DW, __, __},
+		{__, __, __, TL, __, __, __, DW, __, __, __, TL, __, __, __},
+		{TW, __, __, __, DW, __, DL, __, DL, __, DW, __, __, __, TW},
+		{__, TL, __, __, __, TL, __, __, __, TL, __, __, __, TL, __},
+		{__, __, DL, __, DL, __, __, __, __, __, DL, __, DL, __, __},
+		{DL, __, __, DW, __, __, __, __, __, __, __, DW, __, __, DL},
+		{__, __, DL, __, DL, __, __, __, __, __, DL, __, DL, __, __},
+		{__, TL, __, __, __, TL, __, __, __, TL, __, __, __, TL, __},
+		{TW, __, __, __, DW, __, DL, __, DL, __, DW, __, __, __, TW},
+		{__, __, __, TL, __, __, __, DW, __, __, __, TL, __, __, __},
+		{__, __, DW, __, __, __, DL, __, DL, __, __, __, DW, __, __},
+		{__, DL, __, __, __, TL, __, __, __, TL, __, __, __, DL, __},
+		{TL, __, __, __, TW, __, __, DL, __, __, TW, __, __, __, TL},
+	}
+}()
+
+// Square returns the premium square at column, row.
+func (g *Grid) Square(column, row int) Square {
+	return g[row][column]
+}
+
+// InBounds reports whether column, row is a position on the board.
+func InBounds(column, row int) bool {
+	return column >= 0 && column < BoardSize && row >= 0 && row < BoardSize
+}
+
+// Tile is a letter that has been placed on the board.
+type Tile struct {
+	Letter string
+	Blank  bool
+}
+
+// Direction is the direction in which a word is laid out on the board.
+type Direction int
+
+const (
+	Across Direction = 0
+	Down   Direction = 1
+)
+
+func (d Direction) String() string {
+	switch d {
+	case Across:
+		return "across"
+	case Down:
+		return "down"
+	default:
+		return ""
+	}
+}
+
+// Perpendicular returns the direction perpendicular to d.
+func (d Direction) Perpendicular() Direction {
+	if d == Across {
+		return Down
+	}
+	return Across
+}
+
+// step returns the column and row offsets of moving one square in direction d.
+func (d Direction) step() (int, int) {
+	if d == Across {
+		return 1, 0
+	}
+	return 0, 1
+}
+
+// Word is a contiguous run of tiles on the board.
+type Word struct {
+	Column    int
+	Row       int
+	Direction Direction
+	Tiles     []Tile
+}
+
+// String returns the letters of the word.
+func (w Word) String() string {
+	var sb strings.Builder
+	for _, t := range w.Tiles {
+		sb.WriteString(t.Letter)
+	}
+	return sb.String()
+}
+
+// Placements returns the tiles of the word as placements.
+func (w Word) Placements() []Placement {
+	dc, dr := w.Direction.step()
+	placements := make([]Placement, len(w.Tiles))
+	for i, t := range w.Tiles {
+		placements[i] = Place(w.Column+i*dc, w.Row+i*dr, t.Letter, t.Blank)
+	}
+	return placements
+}
+
+// BoardState is the state of a board during a game: the premium squares and the tiles placed on it.
+// The zero value is an empty board without premium squares.
+type BoardState struct {
+	Grid  Grid
+	tiles [BoardSize][BoardSize]Tile
+	count int
+}
+
+// NewBoardState returns a BoardState with the premium squares of grid and tiles placed on it.
+func NewBoardState(grid Grid, tiles []Placement) *BoardState {
+	b := &BoardState{Grid: grid}
+	b.Place(tiles...)
+	return b
+}
+
+// BoardState returns the state of the board of the game. grid is the layout of the board, as returned by
+// Client.Board.
+func (g *Game) BoardState(grid Grid) *BoardState {
+	return NewBoardState(grid, g.Tiles)
+}
+
+// Clone returns a copy of b.
+func (b *BoardState) Clone() *BoardState {
+	c := *b
+	return &c
+}
+
+// Place places tiles on the board, replacing any tiles already on the same squares. Placements that are
+// out of bounds or have no letter are ignored.
+func (b *BoardState) Place(tiles ...Placement) {
+	for _, p := range tiles {
+		if !InBounds(p.Column, p.Row) || p.Letter == "" {
+			continue
+		}
+		if !b.Occupied(p.Column, p.Row) {
+			b.count++
+		}
+		b.tiles[p.Row][p.Column] = Tile{Letter: p.Letter, Blank: p.Blank}
+	}
+}
+
+// Tile returns the tile at column, row. The second return value is false if the square is empty or
+// out of bounds.
+func (b *BoardState) Tile(column, row int) (Tile, bool) {
+	if !b.Occupied(column, row) {
+		return Tile{}, false
+	}
+	return b.tiles[row][column], true
+}
+
+// Occupied reports whether there is a tile at column, row.
+func (b *BoardState) Occupied(column, row int) bool {
+	return InBounds(column, row) && b.tiles[row][column].Letter != ""
+}
+
+// Empty reports whether there are no tiles on the board at all.
+func (b *BoardState) Empty() bool {
+	return b.count == 0
+}
+
+// TileCount returns the number of tiles on the board.
+func (b *BoardState) TileCount() int {
+	return b.count
+}
+
+// Tiles returns all tiles on the board as placements, ordered by row and then column.
+func (b *BoardState) Tiles() []Placement {
+	placements := make([]Placement, 0, b.count)
+	for row := 0; row < BoardSize; row++ {
+		for column := 0; column < BoardSize; column++ {
+			if t, ok := b.Tile(column, row); ok {
+				placements = append(placements, Place(column, row, t.Letter, t.Blank))
+			}
+		}
+	}
+	return placements
+}
+
+// WordAt returns the word in direction d that contains the tile at column, row. The second return value
+// is false if the square is empty. The returned word may consist of a single tile.
+func (b *BoardState) WordAt(column, row int, d Direction) (Word, bool) {
+	if !b.Occupied(column, row) {
+		return Word{}, false
+	}
+
+	dc, dr := d.step()
+	for b.Occupied(column-dc, row-dr) {
+		column -= dc
+		row -= dr
+	}
+
+	w := Word{Column: column, Row: row, Direction: d}
+	for c, r := column, row; b.Occupied(c, r); c, r = c+dc, r+dr {
+		w.Tiles = append(w.Tiles, b.tiles[r][c])
+	}
+	return w, true
+}
+
+// Words returns all words on the board that are at least two letters long, with the across words first.
+func (b *BoardState) Words() []Word {
+	var words []Word
+	for _, d := range []Direction{Across, Down} {
+		dc, dr := d.step()
+		for row := 0; row < BoardSize; row++ {
+			for column := 0; column < BoardSize; column++ {
+				// Only consider squares that start a word.
+				if !b.Occupied(column, row) || b.Occupied(column-dc, row-dr) {
+					continue
+				}
+				w, _ := b.WordAt(column, row, d)
+				if len(w.Tiles) > 1 {
+					words = append(words, w)
+				}
+			}
+		}
+	}
+	return words
+}
+
+// Row returns the words in row that are at least two letters long.
+func (b *BoardState) Row(row int) []Word {
+	return b.line(0, row, Across)
+}
+
+// Column returns the words in column that are at least two letters long.
+func (b *BoardState) Column(column int) []Word {
+	return b.line(column, 0, Down)
+}
+
+func (b *BoardState) line(column, row int, d Direction) []Word {
+	var words []Word
+	dc, dr := d.step()
+	for InBounds(column, row) {
+		w, ok := b.WordAt(column, row, d)
+		if !ok {
+			column += dc
+			row += dr
+			continue
+		}
+		if len(w.Tiles) > 1 {
+			words = append(words, w)
+		}
+		column += len(w.Tiles) * dc
+		row += len(w.Tiles) * dr
+	}
+	return words
+}
@@ -13,6 +13,10 @@ import (
 type Client struct {
 	cl      *http.Client
 	baseURL string
+	retry   *RetryPolicy
+
+	limiter      *limiter
+	sessionLimit *sessionLimiters
 }
 
 type ClientOption func(*Client)
@@ -54,7 +58,8 @@ func (s SessionID) cookie() string {
 // and sent to the API.
 var PasswordSalt = "JarJarBinks9"
 
-func hashPassword(password string) string {
+// HashPassword returns the hash of password that is sent to the API in place of the password itself.
+func HashPassword(password string) string {
 	h := sha1.New()
 	h.Write([]byte(password))
 	h.Write([]byte(PasswordSalt))
@@ -79,29 +84,23 @@ var ErrUnknownEmail = errors.New("unknown_email")
 var ErrUserNotFound = errors.New("user_not_found")
 var ErrWrongPassword = errors.New("wrong_password")
 
-func convertToSentinel(e *apiError) error {
-	m := map[string]error{
-		"access_denied":      ErrAccessDenied,
-		"already_exists":     ErrAlreadyExists,
-		"duplicate_invite":   ErrDuplicateInvite,
-		"game_over":          ErrGameOver,
-		"illegal_move":       ErrIllegalMove,
-		"illegal_tiles":      ErrIllegalTiles,
-		"illegal_user_self":  ErrIllegalUserSelf,
-		"illegal_word":       ErrIllegalWord,
-		"invalid_board_type": ErrInvalidBoardType,
-		"invalid_id":         ErrInvalidID,
-		"invalid_ruleset":    ErrInvalidRuleset,
-		"login_required":     ErrLoginRequired,
-		"not_found":          ErrNotFound,
-		"not_your_turn":      ErrNotYourTurn,
-		"unknown_email":      ErrUnknownEmail,
-		"user_not_found":     ErrUserNotFound,
-		"wrong_password":     ErrWrongPassword,
-	}
-
-	if s, ok := m[e.Type]; ok {
-		return s
-	}
-	return e
+// sentinels maps API error types to their sentinel errors.
+var sentinels = map[string]error{
+	"access_denied":      ErrAccessDenied,
+	"already_exists":     ErrAlreadyExists,
+	"duplicate_invite":   ErrDuplicateInvite,
+	"game_over":          ErrGameOver,
+	"illegal_move":       ErrIllegalMove,
+	"illegal_tiles":      ErrIllegalTiles,
+	"illegal_user_self":  ErrIllegalUserSelf,
+	"illegal_word":       ErrIllegalWord,
+	"invalid_board_type": ErrInvalidBoardType,
+	"invalid_id":         ErrInvalidID,
+	"invalid_ruleset":    ErrInvalidRuleset,
+	"login_required":     ErrLoginRequired,
+	"not_found":          ErrNotFound,
+	"not_your_turn":      ErrNotYourTurn,
+	"unknown_email":      ErrUnknownEmail,
+	"user_not_found":     ErrUserNotFound,
+	"wrong_password":     ErrWrongPassword,
 }
@@ -0,0 +1,442 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kayex/wordfeud"
+)
+
+// app holds the state shared by the commands.
+type app struct {
+	client *wordfeud.Client
+	in     io.Reader
+	out    io.Writer
+	config *config
+}
+
+// session returns the session id stored by the login command.
+func (a *app) session() (wordfeud.SessionID, error) {
+	if a.config == nil {
+		c, err := loadConfig()
+		if err != nil {
+			return "", err
+		}
+		a.config = c
+	}
+	return a.config.Session, nil
+}
+
+// call calls f with the stored session id, and explains how to recover from an expired session.
+func call[T any](a *app, f func(session wordfeud.SessionID) (T, error)) (T, error) {
+	session, err := a.session()
+	if err != nil {
+		return *new(T), err
+	}
+	res, err := f(session)
+	if errors.Is(err, wordfeud.ErrLoginRequired) {
+		return *new(T), fmt.Errorf("%w, run wordfeud login again", err)
+	}
+	return res, err
+}
+
+func usageError(usage string) error {
+	return errors.New("usage: wordfeud " + usage)
+}
+
+func parseID[T ~int64](s string) (T, error) {
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid id %q", s)
+	}
+	return T(id), nil
+}
+
+// gameArg parses the game id argument of commands that take a game id and nothing else.
+func gameArg(args []string, usage string) (wordfeud.GameID, error) {
+	if len(args) != 1 {
+		return 0, usageError(usage)
+	}
+	return parseID[wordfeud.GameID](args[0])
+}
+
+func login(ctx context.Context, a *app, args []string) error {
+	if len(args) != 1 {
+		return usageError(commands["login"].usage)
+	}
+	password, err := readPassword(a.in)
+	if err != nil {
+		return err
+	}
+	session, err := a.client.LoginWithEmail(ctx, args[0], password)
+	if err != nil {
+		return err
+	}
+	err = saveConfig(&config{Session: session})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(a.out, "Logged in as %s\n", args[0])
+	return nil
+}
+
+// readPassword returns the password in the WORDFEUD_PASSWORD environment variable, or else reads it from the
+// first line of r. The password is not taken as an argument, as arguments are visible to other users and end
+// up in the shell history.
+func readPassword(r io.Reader) (string, error) {
+	if p := os.Getenv("WORDFEUD_PASSWORD"); p != "" {
+		return p, nil
+	}
+	fmt.Fprint(os.Stderr, "Password: ")
+	line, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", fmt.Errorf("reading password: %v", err)
+	}
+	password := strings.TrimRight(line, "\r\n")
+	if password == "" {
+		return "", errors.New("empty password")
+	}
+	return password, nil
+}
+
+func games(ctx context.Context, a *app, args []string) error {
+	if len(args) != 0 {
+		return usageError(commands["games"].usage)
+	}
+	gs, err := call(a, func(session wordfeud.SessionID) ([]wordfeud.Game, error) {
+		return a.client.Games(ctx, session)
+	})
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(a.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "GAME\tOPPONENT\tSCORE\tSTATUS\tUPDATED")
+	for _, g := range gs {
+		me, opponent := players(&g)
+		fmt.Fprintf(w, "%d\t%s\t%d-%d\t%s\t%s\n", g.ID, opponent.Username, me.Score, opponent.Score,
+			gameStatus(&g), g.Updated.Local().Format(time.DateTime))
+	}
+	return w.Flush()
+}
+
+// players returns the local player and the opponent of a two player game.
+func players(g *wordfeud.Game) (me, opponent wordfeud.Player) {
+	for _, p := range g.Players {
+		if p.IsLocal {
+			me = p
+		} else {
+			opponent = p
+		}
+	}
+	return me, opponent
+}
+
+func gameStatus(g *wordfeud.Game) string {
+	if !g.IsRunning {
+		// Equal scores are a tie unless the game ended by resignation, in which case EndGame tells who won.
+		me, opponent := players(g)
+		resigned := g.LastMove != nil && g.LastMove.MoveType == wordfeud.MoveTypeResign
+		switch {
+		case me.Score == opponent.Score && !resigned:
+			return "tied"
+		case wordfeud.EndGameStatus(g.EndGame) == wordfeud.EndGameStatusWin:
+			return "won"
+		case wordfeud.EndGameStatus(g.EndGame) == wordfeud.EndGameStatusLoss:
+			return "lost"
+		default:
+			return "finished"
+		}
+	}
+	for _, p := range g.Players {
+		if p.Position == g.CurrentPlayer && p.IsLocal {
+			return "your turn"
+		}
+	}
+	return "their turn"
+}
+
+func show(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("show", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	unicode := fs.Bool("unicode", false, "draw the board using Unicode symbols")
+	err := fs.Parse(args)
+	if err != nil {
+		return usageError(commands["show"].usage)
+	}
+	id, err := gameArg(fs.Args(), commands["show"].usage)
+	if err != nil {
+		return err
+	}
+	g, err := call(a, func(session wordfeud.SessionID) (*wordfeud.Game, error) {
+		return a.client.Game(ctx, session, id)
+	})
+	if err != nil {
+		return err
+	}
+	grid, err := a.client.Board(ctx, g.Board)
+	if err != nil {
+		return err
+	}
+
+	opts := []wordfeud.RenderOption{wordfeud.WithLastMove(g)}
+	if *unicode {
+		opts = append(opts, wordfeud.WithUnicode())
+	}
+	return g.Render(a.out, *grid, opts...)
+}
+
+// formatTiles formats the tiles of a rack, with blanks shown as ?.
+func formatTiles(tiles []string) string {
+	s := make([]string, len(tiles))
+	for i, t := range tiles {
+		s[i] = t
+		if t == wordfeud.BlankTile {
+			s[i] = "?"
+		}
+	}
+	return strings.Join(s, " ")
+}
+
+func move(ctx context.Context, a *app, args []string) error {
+	if len(args) != 3 && len(args) != 4 {
+		return usageError(commands["move"].usage)
+	}
+	id, err := parseID[wordfeud.GameID](args[0])
+	if err != nil {
+		return err
+	}
+	column, row, d, err := wordfeud.ParseCoordinate(args[1])
+	if err != nil {
+		return err
+	}
+	word := args[len(args)-1]
+	if len(args) == 4 {
+		switch strings.ToLower(args[2]) {
+		case "across":
+			d = wordfeud.Across
+		case "down":
+			d = wordfeud.Down
+		default:
+			return fmt.Errorf("invalid direction %q, must be across or down", args[2])
+		}
+	}
+
+	g, err := call(a, func(session wordfeud.SessionID) (*wordfeud.Game, error) {
+		return a.client.Game(ctx, session, id)
+	})
+	if err != nil {
+		return err
+	}
+	placements, err := wordfeud.NewBoardState(wordfeud.Grid{}, g.Tiles).PlaceWord(column, row, d, word)
+	if err != nil {
+		return err
+	}
+
+	res, err := call(a, func(session wordfeud.SessionID) (*wordfeud.MoveResult, error) {
+		return a.client.Move(ctx, session, id, placements)
+	})
+	if err != nil {
+		return err
+	}
+	if res.MainWord != nil {
+		word = *res.MainWord
+	}
+	points := 0
+	if res.Points != nil {
+		points = *res.Points
+	}
+	fmt.Fprintf(a.out, "Played %s for %d points\n", word, points)
+	if len(res.NewTiles) > 0 {
+		fmt.Fprintf(a.out, "New tiles: %s\n", formatTiles(res.NewTiles))
+	}
+	return nil
+}
+
+func pass(ctx context.Context, a *app, args []string) error {
+	id, err := gameArg(args, commands["pass"].usage)
+	if err != nil {
+		return err
+	}
+	_, err = call(a, func(session wordfeud.SessionID) (*wordfeud.MoveResult, error) {
+		return a.client.Pass(ctx, session, id)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(a.out, "Passed")
+	return nil
+}
+
+func swap(ctx context.Context, a *app, args []string) error {
+	if len(args) != 2 {
+		return usageError(commands["swap"].usage)
+	}
+	id, err := parseID[wordfeud.GameID](args[0])
+	if err != nil {
+		return err
+	}
+	var tiles []string
+	for _, r := range strings.ToUpper(args[1]) {
+		if r == '?' {
+			tiles = append(tiles, wordfeud.BlankTile)
+		} else {
+			tiles = append(tiles, string(r))
+		}
+	}
+
+	res, err := call(a, func(session wordfeud.SessionID) (*wordfeud.MoveResult, error) {
+		return a.client.Swap(ctx, session, id, tiles)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(a.out, "Swapped, new tiles: %s\n", formatTiles(res.NewTiles))
+	return nil
+}
+
+func resign(ctx context.Context, a *app, args []string) error {
+	id, err := gameArg(args, commands["resign"].usage)
+	if err != nil {
+		return err
+	}
+	_, err = call(a, func(session wordfeud.SessionID) (*wordfeud.MoveResult, error) {
+		return a.client.Resign(ctx, session, id)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(a.out, "Resigned")
+	return nil
+}
+
+func chat(ctx context.Context, a *app, args []string) error {
+	if len(args) < 1 {
+		return usageError(commands["chat"].usage)
+	}
+	id, err := parseID[wordfeud.GameID](args[0])
+	if err != nil {
+		return err
+	}
+
+	if len(args) > 1 {
+		_, err = call(a, func(session wordfeud.SessionID) (wordfeud.Timestamp, error) {
+			return a.client.SendChatMessage(ctx, session, id, strings.Join(args[1:], " "))
+		})
+		return err
+	}
+
+	g, err := call(a, func(session wordfeud.SessionID) (*wordfeud.Game, error) {
+		return a.client.Game(ctx, session, id)
+	})
+	if err != nil {
+		return err
+	}
+	messages, err := call(a, func(session wordfeud.SessionID) ([]wordfeud.Message, error) {
+		return a.client.ChatMessages(ctx, session, id)
+	})
+	if err != nil {
+		return err
+	}
+	names := make(map[wordfeud.UserID]string, len(g.Players))
+	for _, p := range g.Players {
+		names[p.ID] = p.Username
+	}
+	for _, m := range messages {
+		fmt.Fprintf(a.out, "%s %s: %s\n", m.Sent.Local().Format(time.DateTime), names[m.Sender], m.Message)
+	}
+	return nil
+}
+
+func invite(ctx context.Context, a *app, args []string) error {
+	fs := flag.NewFlagSet("invite", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	ruleset := fs.Int("ruleset", int(wordfeud.RuleSetEnglish), "ruleset id")
+	board := fs.String("board", wordfeud.BoardNormal.String(), "board type, normal or random")
+	err := fs.Parse(args)
+	if err != nil || fs.NArg() != 1 {
+		return usageError(commands["invite"].usage)
+	}
+	var boardID wordfeud.BoardID
+	switch *board {
+	case wordfeud.BoardNormal.String():
+		boardID = wordfeud.BoardNormal
+	case wordfeud.BoardRandom.String():
+		boardID = wordfeud.BoardRandom
+	default:
+		return fmt.Errorf("invalid board %q, must be normal or random", *board)
+	}
+
+	inv, err := call(a, func(session wordfeud.SessionID) (*wordfeud.Invitation, error) {
+		return a.client.Invite(ctx, session, fs.Arg(0), wordfeud.RulesetID(*ruleset), boardID)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(a.out, "Invited %s (invitation %d)\n", fs.Arg(0), inv.ID)
+	return nil
+}
+
+func accept(ctx context.Context, a *app, args []string) error {
+	if len(args) != 1 {
+		return usageError(commands["accept"].usage)
+	}
+	id, err := parseID[wordfeud.InvitationID](args[0])
+	if err != nil {
+		return err
+	}
+	game, err := call(a, func(session wordfeud.SessionID) (wordfeud.GameID, error) {
+		return a.client.AcceptInvitation(ctx, session, id)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(a.out, "Accepted, started game %d\n", game)
+	return nil
+}
+
+func reject(ctx context.Context, a *app, args []string) error {
+	if len(args) != 1 {
+		return usageError(commands["reject"].usage)
+	}
+	id, err := parseID[wordfeud.InvitationID](args[0])
+	if err != nil {
+		return err
+	}
+	_, err = call(a, func(session wordfeud.SessionID) (struct{}, error) {
+		return struct{}{}, a.client.RejectInvitation(ctx, session, id)
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(a.out, "Rejected")
+	return nil
+}
+
+func friends(ctx context.Context, a *app, args []string) error {
+	if len(args) != 0 {
+		return usageError(commands["friends"].usage)
+	}
+	rs, err := call(a, func(session wordfeud.SessionID) ([]wordfeud.Relationship, error) {
+		return a.client.Relationships(ctx, session)
+	})
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(a.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "USER\tID\tWON\tLOST\tTIED")
+	for _, r := range rs {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\n", r.Username, r.UserID, r.GamesWon, r.GamesLost, r.GamesTied)
+	}
+	return w.Flush()
+}
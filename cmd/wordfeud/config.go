@@ -0,0 +1,69 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/kayex/wordfeud"
+)
+
+// config is the state stored between invocations.
+type config struct {
+	Session wordfeud.SessionID `json:"session"`
+}
+
+func configPath() (string, error) {
+	if p := os.Getenv("WORDFEUD_CONFIG"); p != "" {
+		return p, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("finding config directory: %v", err)
+	}
+	return filepath.Join(dir, "wordfeud", "config.json"), nil
+}
+
+func loadConfig() (*config, error) {
+	path, err := configPath()
+	if err != nil {
+		return nil, err
+	}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, errors.New("not logged in, run wordfeud login first")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading config: %v", err)
+	}
+
+	var c config
+	err = json.Unmarshal(b, &c)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshalling config: %v", err)
+	}
+	return &c, nil
+}
+
+func saveConfig(c *config) error {
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0o700)
+	if err != nil {
+		return fmt.Errorf("creating config directory: %v", err)
+	}
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshalling config: %v", err)
+	}
+	err = os.WriteFile(path, b, 0o600)
+	if err != nil {
+		return fmt.Errorf("writing config: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+// Command wordfeud is a command-line client for Wordfeud.
+//
+// Usage:
+//
+//	wordfeud [-url base-url] command [arguments]
+//
+// Run wordfeud without arguments for a list of commands. The login command reads the password from the
+// WORDFEUD_PASSWORD environment variable, or else from standard input. The session it obtains is stored in
+// the user configuration directory, or in the file named by the WORDFEUD_CONFIG environment variable.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+
+	"github.com/kayex/wordfeud"
+)
+
+// command is a subcommand of the wordfeud command.
+type command struct {
+	usage string
+	help  string
+	run   func(ctx context.Context, app *app, args []string) error
+}
+
+var commands map[string]command
+
+func init() {
+	// commands is assigned in init, as the commands refer to it for their usage.
+	commands = map[string]command{
+		"login":   {"login EMAIL", "log in and store the session, reading the password from stdin", login},
+		"games":   {"games", "list games", games},
+		"show":    {"show [-unicode] GAME", "show the board, scores and rack of a game", show},
+		"move":    {"move GAME COORDINATE [across|down] WORD", "play WORD at COORDINATE (e.g. 8H HELLO), lowercase letters are blanks", move},
+		"pass":    {"pass GAME", "pass the turn", pass},
+		"swap":    {"swap GAME TILES", "swap tiles (e.g. AEQ, ? for blank)", swap},
+		"resign":  {"resign GAME", "resign from a game", resign},
+		"chat":    {"chat GAME [MESSAGE...]", "show chat messages, or send MESSAGE", chat},
+		"invite":  {"invite [-ruleset N] [-board normal|random] USERNAME", "invite a user to a game", invite},
+		"accept":  {"accept INVITATION", "accept an invitation", accept},
+		"reject":  {"reject INVITATION", "reject an invitation", reject},
+		"friends": {"friends", "list friends", friends},
+	}
+}
+
+func main() {
+	flag.Usage = usage
+	baseURL := flag.String("url", "", "base URL of the Wordfeud API")
+	flag.Parse()
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	cmd, ok := commands[flag.Arg(0)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "wordfeud: unknown command %q\n", flag.Arg(0))
+		usage()
+		os.Exit(2)
+	}
+
+	var opts []wordfeud.ClientOption
+	if *baseURL != "" {
+		opts = append(opts, wordfeud.WithBaseURL(*baseURL))
+	}
+	a := &app{client: wordfeud.NewClient(opts...), in: os.Stdin, out: os.Stdout}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	err := cmd.run(ctx, a, flag.Args()[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "wordfeud %s: %v\n", flag.Arg(0), err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: wordfeud [-url base-url] command [arguments]\n\ncommands:\n")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-55s %s\n", commands[name].usage, commands[name].help)
+	}
+	fmt.Fprintf(os.Stderr, "\nflags:\n")
+	flag.PrintDefaults()
+}
@@ -0,0 +1,180 @@
+package wordfeud
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// Dictionary is a list of words that are valid in a ruleset. It is safe for concurrent use by multiple
+// goroutines once loaded.
+type Dictionary struct {
+	ruleset  RulesetID
+	alphabet alphabet
+	words    map[string]struct{}
+}
+
+// LoadDictionary reads a plain text word list from r, with one word per line, and returns a Dictionary for
+// ruleset. Words are matched case-insensitively. Empty lines and lines starting with "#" are skipped.
+//
+// Words are normalized to the alphabet of the ruleset: letters written with combining diacritics are
+// composed (so that an A followed by a combining ring above becomes Å), and accented letters that are not
+// part of the alphabet are replaced with their base letter (so that "É" becomes "E" in the French ruleset).
+// Words that still contain letters outside the alphabet after normalization can never be played and are
+// skipped.
+func LoadDictionary(ruleset RulesetID, r io.Reader) (*Dictionary, error) {
+	rs, ok := BuiltinRuleset(ruleset)
+	if !ok {
+		return nil, fmt.Errorf("unknown ruleset %d", ruleset)
+	}
+
+	d := &Dictionary{
+		ruleset:  ruleset,
+		alphabet: newAlphabet(rs.Alphabet),
+		words:    make(map[string]struct{}),
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if w, ok := d.alphabet.normalize(line); ok {
+			d.words[w] = struct{}{}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading word list: %v", err)
+	}
+	return d, nil
+}
+
+// Ruleset returns the ruleset the dictionary was loaded for.
+func (d *Dictionary) Ruleset() RulesetID {
+	return d.ruleset
+}
+
+// Len returns the number of words in the dictionary.
+func (d *Dictionary) Len() int {
+	return len(d.words)
+}
+
+// Contains reports whether word is in the dictionary.
+func (d *Dictionary) Contains(word string) bool {
+	w, ok := d.alphabet.normalize(word)
+	if !ok {
+		return false
+	}
+	_, ok = d.words[w]
+	return ok
+}
+
+// Check checks that move is legal to play on b and that every word it forms is in the dictionary.
+//
+// If the move is illegal, the returned error satisfies errors.Is(err, ErrIllegalMove). If it forms words
+// that are not in the dictionary, the returned error lists them and satisfies errors.Is(err, ErrIllegalWord).
+func (d *Dictionary) Check(b *BoardState, move []Placement) error {
+	return checkWords(b, move, d.Contains)
+}
+
+// checkWords checks that move is legal to play on b and that contains reports true for every word it forms.
+func checkWords(b *BoardState, move []Placement, contains func(string) bool) error {
+	words, err := b.MoveWords(move)
+	if err != nil {
+		return err
+	}
+
+	var illegal []string
+	for _, w := range words {
+		if !contains(w.String()) {
+			illegal = append(illegal, w.String())
+		}
+	}
+	if len(illegal) > 0 {
+		return fmt.Errorf("%w: %s", ErrIllegalWord, strings.Join(illegal, ", "))
+	}
+	return nil
+}
+
+// alphabet maps the letters of a ruleset to their 1-based index in the ruleset alphabet.
+type alphabet map[rune]byte
+
+func newAlphabet(letters []string) alphabet {
+	a := make(alphabet, len(letters))
+	for _, l := range letters {
+		for _, c := range l {
+			a[c] = byte(len(a) + 1)
+		}
+	}
+	return a
+}
+
+// normalize converts word to upper case letters of a. The second return value is false if that is not
+// possible.
+func (a alphabet) normalize(word string) (string, bool) {
+	var letters []rune
+	for _, c := range strings.ToUpper(word) {
+		if unicode.Is(unicode.Mn, c) && len(letters) > 0 {
+			if composed, ok := compositions[[2]rune{letters[len(letters)-1], c}]; ok {
+				letters[len(letters)-1] = composed
+				continue
+			}
+		}
+		letters = append(letters, c)
+	}
+
+	for i, c := range letters {
+		if _, ok := a[c]; ok {
+			continue
+		}
+		base, ok := baseLetters[c]
+		if _, inAlphabet := a[base]; !ok || !inAlphabet {
+			return "", false
+		}
+		letters[i] = base
+	}
+	return string(letters), true
+}
+
+const (
+	combiningGrave      = '\u0300'
+	combiningAcute      = '\u0301'
+	combiningCircumflex = '\u0302'
+	combiningTilde      = '\u0303'
+	combiningDiaeresis  = '\u0308'
+	combiningRing       = '\u030A'
+	combiningCedilla    = '\u0327'
+)
+
+// compositions maps upper case letters followed by a combining diacritic to their precomposed form.
+var compositions = map[[2]rune]rune{
+	{'A', combiningGrave}: 'À', {'A', combiningAcute}: 'Á', {'A', combiningCircumflex}: 'Â',
+	{'A', combiningTilde}: 'Ã', {'A', combiningDiaeresis}: 'Ä', {'A', combiningRing}: 'Å',
+	{'C', combiningCedilla}: 'Ç',
+	{'E', combiningGrave}:   'È', {'E', combiningAcute}: 'É', {'E', combiningCircumflex}: 'Ê',
+	{'E', combiningDiaeresis}: 'Ë',
+	{'I', combiningGrave}:     'Ì', {'I', combiningAcute}: 'Í', {'I', combiningCircumflex}: 'Î',
+	{'I', combiningDiaeresis}: 'Ï',
+	{'N', combiningTilde}:     'Ñ',
+	{'O', combiningGrave}:     'Ò', {'O', combiningAcute}: 'Ó', {'O', combiningCircumflex}: 'Ô',
+	{'O', combiningTilde}: 'Õ', {'O', combiningDiaeresis}: 'Ö',
+	{'U', combiningGrave}: 'Ù', {'U', combiningAcute}: 'Ú', {'U', combiningCircumflex}: 'Û',
+	{'U', combiningDiaeresis}: 'Ü',
+	{'Y', combiningAcute}:     'Ý', {'Y', combiningDiaeresis}: 'Ÿ',
+}
+
+// baseLetters maps accented upper case letters to the letter they are played as in rulesets that do not
+// include them in their alphabet.
+var baseLetters = map[rune]rune{
+	'À': 'A', 'Á': 'A', 'Â': 'A', 'Ã': 'A', 'Ä': 'A', 'Å': 'A',
+	'Ç': 'C',
+	'È': 'E', 'É': 'E', 'Ê': 'E', 'Ë': 'E',
+	'Ì': 'I', 'Í': 'I', 'Î': 'I', 'Ï': 'I',
+	'Ñ': 'N',
+	'Ò': 'O', 'Ó': 'O', 'Ô': 'O', 'Õ': 'O', 'Ö': 'O',
+	'Ù': 'U', 'Ú': 'U', 'Û': 'U', 'Ü': 'U',
+	'Ý': 'Y', 'Ÿ': 'Y',
+}
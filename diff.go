@@ -0,0 +1,93 @@
+package wordfeud
+
+import "fmt"
+
+// GameDiff describes what changed between two snapshots of the same game.
+type GameDiff struct {
+	Game GameID
+	// NewTiles are the tiles on the board in the later snapshot that were not there in the earlier one.
+	NewTiles []Placement
+	// Scores holds the score change of every player, in the order of the players of the later snapshot.
+	Scores []ScoreChange
+	// BagCountDelta is the change in the number of tiles in the bag, which is negative when tiles were drawn.
+	BagCountDelta int
+	// Moves is the number of moves made between the snapshots.
+	Moves int
+	// LastMove is the last move made between the snapshots, or nil if no moves were made.
+	LastMove *Move
+	// CurrentPlayer is the player whose turn it is in the later snapshot.
+	CurrentPlayer PlayerPosition
+	// TurnChanged reports whether it is another player's turn in the later snapshot.
+	TurnChanged bool
+	// Finished reports whether the game ended between the snapshots.
+	Finished bool
+}
+
+// ScoreChange is the change in score of a player.
+type ScoreChange struct {
+	Player UserID
+	Before int
+	After  int
+}
+
+// Delta returns the number of points gained, which is negative if points were lost.
+func (s ScoreChange) Delta() int {
+	return s.After - s.Before
+}
+
+// Changed reports whether anything changed between the snapshots.
+func (d *GameDiff) Changed() bool {
+	if len(d.NewTiles) > 0 || d.BagCountDelta != 0 || d.Moves != 0 || d.TurnChanged || d.Finished {
+		return true
+	}
+	for _, s := range d.Scores {
+		if s.Delta() != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// DiffGames returns what changed in a game between the snapshots before and after, which must be of the
+// same game.
+func DiffGames(before, after *Game) (*GameDiff, error) {
+	if before.ID != after.ID {
+		return nil, fmt.Errorf("snapshots are of different games (%d and %d)", before.ID, after.ID)
+	}
+
+	d := &GameDiff{
+		Game:          after.ID,
+		BagCountDelta: after.BagCount - before.BagCount,
+		Moves:         after.MoveCount - before.MoveCount,
+		CurrentPlayer: after.CurrentPlayer,
+		TurnChanged:   after.CurrentPlayer != before.CurrentPlayer,
+		Finished:      before.IsRunning && !after.IsRunning,
+	}
+	if d.Moves > 0 {
+		d.LastMove = after.LastMove
+	}
+
+	type square struct {
+		column, row int
+	}
+	occupied := make(map[square]bool, len(before.Tiles))
+	for _, t := range before.Tiles {
+		occupied[square{t.Column, t.Row}] = true
+	}
+	for _, t := range after.Tiles {
+		if !occupied[square{t.Column, t.Row}] {
+			d.NewTiles = append(d.NewTiles, t)
+		}
+	}
+
+	for _, p := range after.Players {
+		s := ScoreChange{Player: p.ID, Before: p.Score, After: p.Score}
+		for _, q := range before.Players {
+			if q.ID == p.ID {
+				s.Before = q.Score
+			}
+		}
+		d.Scores = append(d.Scores, s)
+	}
+	return d, nil
+}
@@ -0,0 +1,151 @@
+package wordfeud
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffGames(t *testing.T) {
+	const alice, bob = UserID(1), UserID(2)
+	hello := word(5, 7, Across, "HELLO")
+	pass := &Move{MoveType: MoveTypePass, UserID: bob}
+
+	before := Game{
+		ID:        42,
+		IsRunning: true,
+		MoveCount: 1,
+		BagCount:  80,
+		Tiles:     hello,
+		Players: []Player{
+			{ID: alice, Score: 8, Position: 0},
+			{ID: bob, Score: 0, Position: 1},
+		},
+		CurrentPlayer: 1,
+	}
+
+	tests := []struct {
+		name   string
+		change func(g *Game)
+		want   GameDiff
+		// changed is the expected result of Changed.
+		changed bool
+	}{
+		{
+			name:   "unchanged",
+			change: func(g *Game) {},
+			want: GameDiff{Game: 42, CurrentPlayer: 1, Scores: []ScoreChange{
+				{Player: alice, Before: 8, After: 8},
+				{Player: bob, Before: 0, After: 0},
+			}},
+		},
+		{
+			name: "move",
+			change: func(g *Game) {
+				g.Tiles = append(g.Tiles, word(9, 8, Across, "AX")...)
+				g.MoveCount = 2
+				g.BagCount = 78
+				g.Players[1].Score = 19
+				g.CurrentPlayer = 0
+				g.LastMove = &Move{MoveType: MoveTypeMove, UserID: bob, Move: word(9, 8, Across, "AX")}
+			},
+			want: GameDiff{
+				Game:          42,
+				NewTiles:      word(9, 8, Across, "AX"),
+				BagCountDelta: -2,
+				Moves:         1,
+				LastMove:      &Move{MoveType: MoveTypeMove, UserID: bob, Move: word(9, 8, Across, "AX")},
+				CurrentPlayer: 0,
+				TurnChanged:   true,
+				Scores: []ScoreChange{
+					{Player: alice, Before: 8, After: 8},
+					{Player: bob, Before: 0, After: 19},
+				},
+			},
+			changed: true,
+		},
+		{
+			name: "pass",
+			change: func(g *Game) {
+				g.MoveCount = 2
+				g.CurrentPlayer = 0
+				g.LastMove = pass
+			},
+			want: GameDiff{Game: 42, Moves: 1, LastMove: pass, CurrentPlayer: 0, TurnChanged: true, Scores: []ScoreChange{
+				{Player: alice, Before: 8, After: 8},
+				{Player: bob, Before: 0, After: 0},
+			}},
+			changed: true,
+		},
+		{
+			// A last move in an unchanged snapshot was already made before it, and is not reported.
+			name: "last move without new moves",
+			change: func(g *Game) {
+				g.LastMove = pass
+			},
+			want: GameDiff{Game: 42, CurrentPlayer: 1, Scores: []ScoreChange{
+				{Player: alice, Before: 8, After: 8},
+				{Player: bob, Before: 0, After: 0},
+			}},
+		},
+		{
+			name: "finished",
+			change: func(g *Game) {
+				g.IsRunning = false
+				g.MoveCount = 2
+				g.LastMove = &Move{MoveType: MoveTypeResign, UserID: bob}
+			},
+			want: GameDiff{
+				Game:          42,
+				Moves:         1,
+				LastMove:      &Move{MoveType: MoveTypeResign, UserID: bob},
+				CurrentPlayer: 1,
+				Finished:      true,
+				Scores: []ScoreChange{
+					{Player: alice, Before: 8, After: 8},
+					{Player: bob, Before: 0, After: 0},
+				},
+			},
+			changed: true,
+		},
+		{
+			// Scores are in the order of the later snapshot.
+			name: "players reordered",
+			change: func(g *Game) {
+				g.Players[0], g.Players[1] = g.Players[1], g.Players[0]
+				g.Players[0].Score = 3
+			},
+			want: GameDiff{Game: 42, CurrentPlayer: 1, Scores: []ScoreChange{
+				{Player: bob, Before: 0, After: 3},
+				{Player: alice, Before: 8, After: 8},
+			}},
+			changed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			after := before
+			after.Tiles = append([]Placement(nil), before.Tiles...)
+			after.Players = append([]Player(nil), before.Players...)
+			tt.change(&after)
+
+			d, err := DiffGames(&before, &after)
+			if err != nil {
+				t.Fatalf("DiffGames returned error: %v", err)
+			}
+			if !reflect.DeepEqual(*d, tt.want) {
+				t.Errorf("got diff\n%+v\nwant\n%+v", *d, tt.want)
+			}
+			if d.Changed() != tt.changed {
+				t.Errorf("Changed() = %v, want %v", d.Changed(), tt.changed)
+			}
+		})
+	}
+}
+
+func TestDiffGamesDifferentGames(t *testing.T) {
+	_, err := DiffGames(&Game{ID: 1}, &Game{ID: 2})
+	if err == nil {
+		t.Error("DiffGames returned no error for snapshots of different games")
+	}
+}
@@ -2,6 +2,7 @@ package wordfeud
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -10,7 +11,7 @@ import (
 )
 
 // CreateAccount creates a new Wordfeud account.
-func (c *Client) CreateAccount(username, email, password string) (*Login, SessionID, error) {
+func (c *Client) CreateAccount(ctx context.Context, username, email, password string) (*Login, SessionID, error) {
 	body, err := json.Marshal(struct {
 		Username string `json:"username"`
 		Email    string `json:"email"`
@@ -18,12 +19,12 @@ func (c *Client) CreateAccount(username, email, password string) (*Login, Sessio
 	}{
 		Username: username,
 		Email:    email,
-		Password: hashPassword(password),
+		Password: HashPassword(password),
 	})
 	if err != nil {
 		return nil, "", fmt.Errorf("marshalling request body: %v", err)
 	}
-	res, err := c.request(http.MethodPost, "/user/create", "", body)
+	res, err := c.request(ctx, http.MethodPost, "/user/create", "", body)
 	if err != nil {
 		return nil, "", err
 	}
@@ -43,19 +44,23 @@ func (c *Client) CreateAccount(username, email, password string) (*Login, Sessio
 }
 
 // LoginWithEmail authenticates a user with email and password.
-func (c *Client) LoginWithEmail(email, password string) (SessionID, error) {
+func (c *Client) LoginWithEmail(ctx context.Context, email, password string) (SessionID, error) {
+	return c.loginWithEmail(ctx, email, HashPassword(password))
+}
+
+func (c *Client) loginWithEmail(ctx context.Context, email, passwordHash string) (SessionID, error) {
 	body, err := json.Marshal(struct {
 		Email    string `json:"email"`
 		Password string `json:"password"`
 	}{
 		Email:    email,
-		Password: hashPassword(password),
+		Password: passwordHash,
 	})
 	if err != nil {
 		return "", fmt.Errorf("marshalling request body: %v", err)
 	}
 
-	res, err := c.request(http.MethodPost, "/user/login/email", "", body)
+	res, err := c.request(ctx, http.MethodPost, "/user/login/email", "", body)
 	if err != nil {
 		return "", err
 	}
@@ -64,19 +69,23 @@ func (c *Client) LoginWithEmail(email, password string) (SessionID, error) {
 }
 
 // LoginWithID authenticates a user with id and password.
-func (c *Client) LoginWithID(id UserID, password string) (SessionID, error) {
+func (c *Client) LoginWithID(ctx context.Context, id UserID, password string) (SessionID, error) {
+	return c.loginWithID(ctx, id, HashPassword(password))
+}
+
+func (c *Client) loginWithID(ctx context.Context, id UserID, passwordHash string) (SessionID, error) {
 	body, err := json.Marshal(struct {
 		ID       UserID `json:"id"`
 		Password string `json:"password"`
 	}{
 		ID:       id,
-		Password: hashPassword(password),
+		Password: passwordHash,
 	})
 	if err != nil {
 		return "", fmt.Errorf("marshalling request body: %v", err)
 	}
 
-	res, err := c.request(http.MethodPost, "/user/login/id", "", body)
+	res, err := c.request(ctx, http.MethodPost, "/user/login/id", "", body)
 	if err != nil {
 		return "", err
 	}
@@ -85,20 +94,20 @@ func (c *Client) LoginWithID(id UserID, password string) (SessionID, error) {
 }
 
 // ChangePassword changes the password of the user authenticated by session.
-func (c *Client) ChangePassword(session SessionID, newPassword string) error {
+func (c *Client) ChangePassword(ctx context.Context, session SessionID, newPassword string) error {
 	body, err := json.Marshal(struct {
 		Password string `json:"password"`
-	}{hashPassword(newPassword)})
+	}{HashPassword(newPassword)})
 	if err != nil {
 		return fmt.Errorf("marshalling request body: %v", err)
 	}
-	_, err = c.request(http.MethodPost, "/user/password/set", session, body)
+	_, err = c.request(ctx, http.MethodPost, "/user/password/set", session, body)
 	return err
 }
 
 // UpdateAvatar updates the avatar of the user authenticated by session and returns the time it was
 // updated, as reported by the server.
-func (c *Client) UpdateAvatar(session SessionID, image io.Reader) (Timestamp, error) {
+func (c *Client) UpdateAvatar(ctx context.Context, session SessionID, image io.Reader) (Timestamp, error) {
 	var encoded bytes.Buffer
 	encoder := base64.NewEncoder(base64.StdEncoding, &encoded)
 	_, err := io.Copy(encoder, image)
@@ -112,7 +121,7 @@ func (c *Client) UpdateAvatar(session SessionID, image io.Reader) (Timestamp, er
 
 	res, err := roundtrip[struct {
 		AvatarUpdated Timestamp `json:"avatar_updated"`
-	}](c, http.MethodPost, "/user/avatar/upload", session, struct {
+	}](ctx, c, http.MethodPost, "/user/avatar/upload", session, struct {
 		ImageData string `json:"image_data"`
 	}{encoded.String()})
 	if err != nil {
@@ -122,10 +131,10 @@ func (c *Client) UpdateAvatar(session SessionID, image io.Reader) (Timestamp, er
 }
 
 // Relationships returns all the friends of the user authenticated by session.
-func (c *Client) Relationships(session SessionID) ([]Relationship, error) {
+func (c *Client) Relationships(ctx context.Context, session SessionID) ([]Relationship, error) {
 	res, err := roundtrip[struct {
 		Relationships []Relationship `json:"relationships"`
-	}](c, http.MethodGet, "/user/relationships", session, nil)
+	}](ctx, c, http.MethodGet, "/user/relationships", session, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -133,36 +142,42 @@ func (c *Client) Relationships(session SessionID) ([]Relationship, error) {
 }
 
 // CreateRelationship adds a user to the friends list.
-func (c *Client) CreateRelationship(session SessionID, user UserID) (*Relationship, error) {
-	return roundtrip[Relationship](c, http.MethodPost, "/relationship/create", session, struct {
+func (c *Client) CreateRelationship(ctx context.Context, session SessionID, user UserID) (*Relationship, error) {
+	return roundtrip[Relationship](ctx, c, http.MethodPost, "/relationship/create", session, struct {
 		ID   UserID `json:"id"`
 		Type int    `json:"type"`
 	}{ID: user, Type: 0})
 }
 
 // DeleteRelationship removes a user from the friends list.
-func (c *Client) DeleteRelationship(session SessionID, user UserID) error {
-	_, err := c.request(http.MethodPost, fmt.Sprintf("/relationship/%d/delete", user), session, nil)
+func (c *Client) DeleteRelationship(ctx context.Context, session SessionID, user UserID) error {
+	_, err := c.request(ctx, http.MethodPost, fmt.Sprintf("/relationship/%d/delete", user), session, nil)
 	return err
 }
 
 // Games returns all ongoing games the user authenticated by session is participating in, as well as recently
 // finished ones.
-func (c *Client) Games(session SessionID) ([]Game, error) {
+func (c *Client) Games(ctx context.Context, session SessionID) ([]Game, error) {
 	res, err := roundtrip[struct {
 		Games []Game `json:"games"`
-	}](c, http.MethodGet, "/user/games", session, nil)
+	}](ctx, c, http.MethodGet, "/user/games", session, nil)
 	if err != nil {
 		return nil, err
 	}
 	return res.Games, nil
 }
 
+// Status returns a summary of the games and invitations of the user authenticated by session. It is a lot
+// cheaper than Games and is suitable for polling for changes.
+func (c *Client) Status(ctx context.Context, session SessionID) (*Status, error) {
+	return roundtrip[Status](ctx, c, http.MethodGet, "/user/status", session, nil)
+}
+
 // Game returns a single game.
-func (c *Client) Game(session SessionID, game GameID) (*Game, error) {
+func (c *Client) Game(ctx context.Context, session SessionID, game GameID) (*Game, error) {
 	res, err := roundtrip[struct {
 		Game Game `json:"game"`
-	}](c, http.MethodGet, fmt.Sprintf("/game/%d", game), session, nil)
+	}](ctx, c, http.MethodGet, fmt.Sprintf("/game/%d", game), session, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -170,10 +185,10 @@ func (c *Client) Game(session SessionID, game GameID) (*Game, error) {
 }
 
 // Invite invites a player to a new game by username.
-func (c *Client) Invite(session SessionID, username string, ruleset RulesetID, board BoardID) (*Invitation, error) {
+func (c *Client) Invite(ctx context.Context, session SessionID, username string, ruleset RulesetID, board BoardID) (*Invitation, error) {
 	res, err := roundtrip[struct {
 		Invitation Invitation `json:"invitation"`
-	}](c, http.MethodPost, "/invite/new", session, struct {
+	}](ctx, c, http.MethodPost, "/invite/new", session, struct {
 		Invitee   string    `json:"invitee"`
 		Ruleset   RulesetID `json:"ruleset"`
 		BoardType string    `json:"board_type"`
@@ -189,10 +204,10 @@ func (c *Client) Invite(session SessionID, username string, ruleset RulesetID, b
 }
 
 // InviteRandomOpponent invites a random opponent to a new game.
-func (c *Client) InviteRandomOpponent(session SessionID, ruleset RulesetID, board BoardID) (*Invitation, error) {
+func (c *Client) InviteRandomOpponent(ctx context.Context, session SessionID, ruleset RulesetID, board BoardID) (*Invitation, error) {
 	res, err := roundtrip[struct {
 		Invitation Invitation `json:"invitation"`
-	}](c, http.MethodPost, "random_request/create", session, struct {
+	}](ctx, c, http.MethodPost, "random_request/create", session, struct {
 		Ruleset   RulesetID `json:"ruleset"`
 		BoardType string    `json:"board_type"`
 	}{
@@ -206,10 +221,10 @@ func (c *Client) InviteRandomOpponent(session SessionID, ruleset RulesetID, boar
 }
 
 // AcceptInvitation accepts a game invitation and returns the id of the resulting game.
-func (c *Client) AcceptInvitation(session SessionID, invitation InvitationID) (GameID, error) {
+func (c *Client) AcceptInvitation(ctx context.Context, session SessionID, invitation InvitationID) (GameID, error) {
 	res, err := roundtrip[struct {
 		ID GameID `json:"id"`
-	}](c, http.MethodPost, fmt.Sprintf("/invite/%d/accept", invitation), session, nil)
+	}](ctx, c, http.MethodPost, fmt.Sprintf("/invite/%d/accept", invitation), session, nil)
 	if err != nil {
 		return 0, err
 	}
@@ -217,13 +232,13 @@ func (c *Client) AcceptInvitation(session SessionID, invitation InvitationID) (G
 }
 
 // RejectInvitation rejects a game invitation.
-func (c *Client) RejectInvitation(session SessionID, invitation InvitationID) error {
-	_, err := c.request(http.MethodPost, fmt.Sprintf("/invite/%d/reject", invitation), session, nil)
+func (c *Client) RejectInvitation(ctx context.Context, session SessionID, invitation InvitationID) error {
+	_, err := c.request(ctx, http.MethodPost, fmt.Sprintf("/invite/%d/reject", invitation), session, nil)
 	return err
 }
 
 // Move performs a move.
-func (c *Client) Move(session SessionID, game GameID, move []Placement) (*MoveResult, error) {
+func (c *Client) Move(ctx context.Context, session SessionID, game GameID, move []Placement) (*MoveResult, error) {
 	// The API crashes without any actionable error information when attempting to place multiple tiles
 	// on the same square. Since this error is pretty hard to debug, we do a check for it here.
 	if !uniqueSquares(move) {
@@ -235,26 +250,38 @@ func (c *Client) Move(session SessionID, game GameID, move []Placement) (*MoveRe
 		placements = append(placements, p.Array())
 	}
 
-	return roundtrip[MoveResult](c, http.MethodPost, fmt.Sprintf("/game/%d/move", game), session, struct {
+	return roundtrip[MoveResult](ctx, c, http.MethodPost, fmt.Sprintf("/game/%d/move", game), session, struct {
 		Move [][4]any `json:"move"`
 	}{placements})
 }
 
 // Pass passes the turn to the opponent.
-func (c *Client) Pass(session SessionID, game GameID) (*MoveResult, error) {
-	return roundtrip[MoveResult](c, http.MethodPost, fmt.Sprintf("/game/%d/pass", game), session, nil)
+func (c *Client) Pass(ctx context.Context, session SessionID, game GameID) (*MoveResult, error) {
+	return roundtrip[MoveResult](ctx, c, http.MethodPost, fmt.Sprintf("/game/%d/pass", game), session, nil)
+}
+
+// Swap exchanges tiles from the rack with new ones from the bag, passing the turn to the opponent.
+// The new tiles are available in the NewTiles field of the returned MoveResult.
+func (c *Client) Swap(ctx context.Context, session SessionID, game GameID, tiles []string) (*MoveResult, error) {
+	if len(tiles) == 0 {
+		return nil, ErrIllegalTiles
+	}
+
+	return roundtrip[MoveResult](ctx, c, http.MethodPost, fmt.Sprintf("/game/%d/swap", game), session, struct {
+		Tiles []string `json:"tiles"`
+	}{tiles})
 }
 
 // Resign resigns from a game.
-func (c *Client) Resign(session SessionID, game GameID) (*MoveResult, error) {
-	return roundtrip[MoveResult](c, http.MethodPost, fmt.Sprintf("/game/%d/resign", game), session, nil)
+func (c *Client) Resign(ctx context.Context, session SessionID, game GameID) (*MoveResult, error) {
+	return roundtrip[MoveResult](ctx, c, http.MethodPost, fmt.Sprintf("/game/%d/resign", game), session, nil)
 }
 
 // ChatMessages returns all the chat messages sent in a game.
-func (c *Client) ChatMessages(session SessionID, game GameID) ([]Message, error) {
+func (c *Client) ChatMessages(ctx context.Context, session SessionID, game GameID) ([]Message, error) {
 	res, err := roundtrip[struct {
 		Messages []Message `json:"messages"`
-	}](c, http.MethodGet, fmt.Sprintf("/user/%d/chat", game), session, nil)
+	}](ctx, c, http.MethodGet, fmt.Sprintf("/user/%d/chat", game), session, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -262,10 +289,10 @@ func (c *Client) ChatMessages(session SessionID, game GameID) ([]Message, error)
 }
 
 // SendChatMessage sends a chat message and returns the time it was sent, as reported by the server.
-func (c *Client) SendChatMessage(session SessionID, game GameID, message string) (Timestamp, error) {
+func (c *Client) SendChatMessage(ctx context.Context, session SessionID, game GameID, message string) (Timestamp, error) {
 	res, err := roundtrip[struct {
 		Sent Timestamp `json:"sent"`
-	}](c, http.MethodPost, fmt.Sprintf("/game/%d/chat/send", game), session, struct {
+	}](ctx, c, http.MethodPost, fmt.Sprintf("/game/%d/chat/send", game), session, struct {
 		Message string `json:"message"`
 	}{message})
 	if err != nil {
@@ -275,12 +302,28 @@ func (c *Client) SendChatMessage(session SessionID, game GameID, message string)
 }
 
 // Board returns the layout of a board.
-func (c *Client) Board(board BoardID) (*Grid, error) {
+func (c *Client) Board(ctx context.Context, board BoardID) (*Grid, error) {
 	res, err := roundtrip[struct {
 		Board Grid `json:"board"`
-	}](c, http.MethodGet, fmt.Sprintf("/board/%d", board), "", nil)
+	}](ctx, c, http.MethodGet, fmt.Sprintf("/board/%d", board), "", nil)
 	if err != nil {
 		return nil, err
 	}
 	return &res.Board, nil
 }
+
+// Ruleset returns the tile distribution and letter values of a ruleset. BuiltinRuleset can be used
+// to get the same information without making a request.
+//
+// The /ruleset/{id} endpoint and the keys of its response have not been verified against the real API,
+// which may not serve them at all. Only the wordfeudtest fake server is known to answer it, so prefer
+// BuiltinRuleset.
+func (c *Client) Ruleset(ctx context.Context, ruleset RulesetID) (*Ruleset, error) {
+	res, err := roundtrip[struct {
+		Ruleset Ruleset `json:"ruleset"`
+	}](ctx, c, http.MethodGet, fmt.Sprintf("/ruleset/%d", ruleset), "", nil)
+	if err != nil {
+		return nil, err
+	}
+	return &res.Ruleset, nil
+}
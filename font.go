@@ -0,0 +1,107 @@
+package wordfeud
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// fontWidth and fontHeight are the size in pixels of the glyphs of the bitmap font used to draw text on
+// images, before scaling.
+const (
+	fontWidth  = 5
+	fontHeight = 7
+)
+
+// font is a bitmap font covering the letters of the built-in rulesets and the digits. Letters with
+// diacritics are composed of a base letter and a mark from diacritics.
+var font = map[rune][fontHeight]string{
+	'A': {".###.", "#...#", "#...#", "#####", "#...#", "#...#", "#...#"},
+	'B': {"####.", "#...#", "#...#", "####.", "#...#", "#...#", "####."},
+	'C': {".###.", "#...#", "#....", "#....", "#....", "#...#", ".###."},
+	'D': {"####.", "#...#", "#...#", "#...#", "#...#", "#...#", "####."},
+	'E': {"#####", "#....", "#....", "####.", "#....", "#....", "#####"},
+	'F': {"#####", "#....", "#....", "####.", "#....", "#....", "#...."},
+	'G': {".###.", "#...#", "#....", "#.###", "#...#", "#...#", ".####"},
+	'H': {"#...#", "#...#", "#...#", "#####", "#...#", "#...#", "#...#"},
+	'I': {".###.", "..#..", "..#..", "..#..", "..#..", "..#..", ".###."},
+	'J': {"..###", "...#.", "...#.", "...#.", "...#.", "#..#.", ".##.."},
+	'K': {"#...#", "#..#.", "#.#..", "##...", "#.#..", "#..#.", "#...#"},
+	'L': {"#....", "#....", "#....", "#....", "#....", "#....", "#####"},
+	'M': {"#...#", "##.##", "#.#.#", "#.#.#", "#...#", "#...#", "#...#"},
+	'N': {"#...#", "#...#", "##..#", "#.#.#", "#..##", "#...#", "#...#"},
+	'O': {".###.", "#...#", "#...#", "#...#", "#...#", "#...#", ".###."},
+	'P': {"####.", "#...#", "#...#", "####.", "#....", "#....", "#...."},
+	'Q': {".###.", "#...#", "#...#", "#...#", "#.#.#", "#..#.", ".##.#"},
+	'R': {"####.", "#...#", "#...#", "####.", "#.#..", "#..#.", "#...#"},
+	'S': {".####", "#....", "#....", ".###.", "....#", "....#", "####."},
+	'T': {"#####", "..#..", "..#..", "..#..", "..#..", "..#..", "..#.."},
+	'U': {"#...#", "#...#", "#...#", "#...#", "#...#", "#...#", ".###."},
+	'V': {"#...#", "#...#", "#...#", "#...#", "#...#", ".#.#.", "..#.."},
+	'W': {"#...#", "#...#", "#...#", "#.#.#", "#.#.#", "#.#.#", ".#.#."},
+	'X': {"#...#", "#...#", ".#.#.", "..#..", ".#.#.", "#...#", "#...#"},
+	'Y': {"#...#", "#...#", ".#.#.", "..#..", "..#..", "..#..", "..#.."},
+	'Z': {"#####", "....#", "...#.", "..#..", ".#...", "#....", "#####"},
+	'Æ': {".####", "#.#..", "#.#..", "#####", "#.#..", "#.#..", "#.###"},
+	'Ø': {".###.", "#...#", "#..##", "#.#.#", "##..#", "#...#", ".###."},
+	'0': {".###.", "#...#", "#...#", "#...#", "#...#", "#...#", ".###."},
+	'1': {"..#..", ".##..", "..#..", "..#..", "..#..", "..#..", ".###."},
+	'2': {".###.", "#...#", "....#", "...#.", "..#..", ".#...", "#####"},
+	'3': {"####.", "....#", "....#", ".###.", "....#", "....#", "####."},
+	'4': {"...#.", "..##.", ".#.#.", "#..#.", "#####", "...#.", "...#."},
+	'5': {"#####", "#....", "####.", "....#", "....#", "#...#", ".###."},
+	'6': {".###.", "#....", "#....", "####.", "#...#", "#...#", ".###."},
+	'7': {"#####", "....#", "...#.", "..#..", ".#...", ".#...", ".#..."},
+	'8': {".###.", "#...#", "#...#", ".###.", "#...#", "#...#", ".###."},
+	'9': {".###.", "#...#", "#...#", ".####", "....#", "....#", ".###."},
+}
+
+// diacritics maps letters with diacritics to their base letter and the mark drawn above it.
+var diacritics = map[rune]struct {
+	base rune
+	mark []string
+}{
+	'Ä': {'A', []string{".#.#."}},
+	'Ö': {'O', []string{".#.#."}},
+	'Å': {'A', []string{"..#..", ".#.#.", "..#.."}},
+	'Ñ': {'N', []string{".##.#", "#.##."}},
+}
+
+// textWidth returns the width in pixels of s when drawn by drawText at scale.
+func textWidth(s string, scale int) int {
+	n := len([]rune(s))
+	if n == 0 {
+		return 0
+	}
+	return (n*(fontWidth+1) - 1) * scale
+}
+
+// drawText draws s onto img with its top left corner at x, y, scaling every pixel of the font to a square
+// of scale by scale pixels. Diacritics are drawn above y. Characters missing from the font are left blank.
+func drawText(img draw.Image, x, y int, s string, scale int, c color.Color) {
+	for _, r := range s {
+		base := r
+		if d, ok := diacritics[r]; ok {
+			base = d.base
+			// The mark is drawn with one empty row between it and the letter.
+			drawBitmap(img, x, y-(len(d.mark)+1)*scale, d.mark, scale, c)
+		}
+		if g, ok := font[base]; ok {
+			drawBitmap(img, x, y, g[:], scale, c)
+		}
+		x += (fontWidth + 1) * scale
+	}
+}
+
+func drawBitmap(img draw.Image, x, y int, rows []string, scale int, c color.Color) {
+	src := image.NewUniform(c)
+	for i, row := range rows {
+		for j, px := range row {
+			if px != '#' {
+				continue
+			}
+			r := image.Rect(x+j*scale, y+i*scale, x+(j+1)*scale, y+(i+1)*scale)
+			draw.Draw(img, r, src, image.Point{}, draw.Src)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package wordfeud
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// PlayedMove is a move of a game, together with the rack it was played from when it is known.
+type PlayedMove struct {
+	Move
+	// Rack is the rack of the player before the move, with blanks represented by BlankTile, or nil if it is
+	// unknown.
+	Rack []string `json:"rack,omitempty"`
+}
+
+// WriteGCG writes g to w in the GCG format used by Scrabble analysis tools. moves must be all the moves of
+// the game, in the order they were played.
+//
+// Moves are written with their coordinates, word and score, using . for tiles that were already on the
+// board. Unknown racks are written as an empty rack field. Swaps are written as passes followed by a
+// note, and resignations as notes. When g is finished, the points gained and lost for tiles left on the
+// racks are written as well, as notes if the tiles are unknown.
+func (g *Game) WriteGCG(w io.Writer, moves []PlayedMove) error {
+	players := slices.Clone(g.Players)
+	slices.SortFunc(players, func(a, b Player) int {
+		return int(a.Position - b.Position)
+	})
+	nicks := make(map[UserID]string, len(players))
+	totals := make(map[UserID]int, len(players))
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "#character-encoding UTF-8")
+	for i, p := range players {
+		// Nicknames may not contain spaces.
+		nicks[p.ID] = strings.ReplaceAll(p.Username, " ", "_")
+		fmt.Fprintf(bw, "#player%d %s %s\n", i+1, nicks[p.ID], p.Username)
+	}
+	fmt.Fprintf(bw, "#id wordfeud %d\n", g.ID)
+
+	board := &BoardState{}
+	for i, m := range moves {
+		nick, ok := nicks[m.UserID]
+		if !ok {
+			return fmt.Errorf("move %d: user %d is not a player of game %d", i+1, m.UserID, g.ID)
+		}
+
+		var play string
+		points := 0
+		switch m.MoveType {
+		case MoveTypeMove:
+			var err error
+			play, err = board.gcgPlay(m.Move.Move)
+			if err != nil {
+				return fmt.Errorf("move %d: %w", i+1, err)
+			}
+			if m.Points == nil {
+				return fmt.Errorf("move %d: points are unknown", i+1)
+			}
+			points = *m.Points
+			board.Place(m.Move.Move...)
+		case MoveTypePass:
+			play = "-"
+		case MoveTypeSwap:
+			// The exchanged tiles are unknown, and GCG has no notation for exchanging an unknown number
+			// of tiles, so swaps are written as passes followed by a note.
+			play = "-"
+		case MoveTypeResign:
+			fmt.Fprintf(bw, "#note %s resigned\n", nick)
+			continue
+		default:
+			return fmt.Errorf("move %d: unknown move type %q", i+1, m.MoveType)
+		}
+
+		totals[m.UserID] += points
+		// The rack field is required, and left empty when the rack is unknown.
+		fmt.Fprintf(bw, ">%s: %s %s %+d %d\n", nick, gcgRack(m.Rack), play, points, totals[m.UserID])
+		if m.MoveType == MoveTypeSwap {
+			if m.TileCount != nil {
+				fmt.Fprintf(bw, "#note %s exchanged %d tiles\n", nick, *m.TileCount)
+			} else {
+				fmt.Fprintf(bw, "#note %s exchanged tiles\n", nick)
+			}
+		}
+	}
+
+	if !g.IsRunning {
+		writeGCGEndGame(bw, players, nicks, totals)
+	}
+	return bw.Flush()
+}
+
+// writeGCGEndGame writes the difference between the final score of each player and the points they scored
+// by their moves, which is the value of the tiles left on the racks at the end of the game.
+func writeGCGEndGame(w io.Writer, players []Player, nicks map[UserID]string, totals map[UserID]int) {
+	for _, p := range players {
+		delta := p.Score - totals[p.ID]
+		if delta == 0 {
+			continue
+		}
+
+		// Points are lost for the tiles left on the rack of the player, and gained for those left on the
+		// racks of the other players when the player emptied their own. Racks are only known for the
+		// local player.
+		var tiles []string
+		known := true
+		for _, q := range players {
+			if (delta < 0) != (q.ID == p.ID) {
+				continue
+			}
+			if !q.IsLocal {
+				known = false
+			}
+			tiles = append(tiles, q.Rack...)
+		}
+		if known && len(tiles) > 0 {
+			fmt.Fprintf(w, ">%s: (%s) %+d %d\n", nicks[p.ID], gcgRack(tiles), delta, p.Score)
+		} else {
+			fmt.Fprintf(w, "#note %s: %+d for tiles left on the racks, final score %d\n", nicks[p.ID], delta, p.Score)
+		}
+	}
+}
+
+// gcgRack returns tiles in GCG notation, with blanks written as ?.
+func gcgRack(tiles []string) string {
+	var sb strings.Builder
+	for _, t := range tiles {
+		if t == BlankTile {
+			t = "?"
+		}
+		sb.WriteString(t)
+	}
+	return sb.String()
+}
+
+// gcgPlay returns the coordinate and word of move played on b in GCG notation, which is the standard
+// notation of FormatMove with tiles already on the board written as . instead of in parentheses.
+func (b *BoardState) gcgPlay(move []Placement) (string, error) {
+	main, _, err := b.formedWords(move)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(FormatCoordinate(main.Column, main.Row, main.Direction))
+	sb.WriteByte(' ')
+	dc, dr := main.Direction.step()
+	for i, t := range main.Tiles {
+		switch {
+		case b.Occupied(main.Column+i*dc, main.Row+i*dr):
+			sb.WriteByte('.')
+		case t.Blank:
+			sb.WriteString(strings.ToLower(t.Letter))
+		default:
+			sb.WriteString(t.Letter)
+		}
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,101 @@
+package wordfeud
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestWriteGCG(t *testing.T) {
+	const alice, bob = UserID(1), UserID(2)
+	moves := []PlayedMove{
+		{
+			Move: Move{MoveType: MoveTypeMove, UserID: alice, Move: word(5, 7, Across, "HELLO"), MainWord: strPtr("HELLO"), Points: intPtr(8)},
+			Rack: []string{"H", "E", "L", "L", "O", "A", BlankTile},
+		},
+		{Move: Move{MoveType: MoveTypePass, UserID: bob}},
+		{
+			Move: Move{MoveType: MoveTypeSwap, UserID: alice, TileCount: intPtr(2)},
+			Rack: []string{"A", BlankTile, "Q", "U", "I", "Z", "X"},
+		},
+		{Move: Move{MoveType: MoveTypeMove, UserID: bob, Move: word(10, 7, Across, "s"), MainWord: strPtr("HELLOS"), Points: intPtr(8)}},
+		{
+			Move: Move{MoveType: MoveTypeMove, UserID: alice, Move: word(6, 8, Down, "T"), MainWord: strPtr("ET"), Points: intPtr(2)},
+			Rack: []string{"T", "Q"},
+		},
+	}
+
+	tests := []struct {
+		name string
+		game Game
+	}{
+		{
+			name: "running",
+			game: Game{ID: 42, IsRunning: true, Players: []Player{
+				{ID: bob, Username: "bob", Score: 8, Position: 1},
+				{ID: alice, Username: "Alice Smith", Score: 10, Position: 0, IsLocal: true, Rack: []string{"Q"}},
+			}},
+		},
+		{
+			// Alice is left with Q, worth 10 points, which bob gains. Her rack is known.
+			name: "finished",
+			game: Game{ID: 42, Players: []Player{
+				{ID: alice, Username: "Alice Smith", Score: 0, Position: 0, IsLocal: true, Rack: []string{"Q"}},
+				{ID: bob, Username: "bob", Score: 18, Position: 1},
+			}},
+		},
+		{
+			// The same end as above from the point of view of bob, who does not know the rack of alice.
+			name: "finished_unknown_racks",
+			game: Game{ID: 42, Players: []Player{
+				{ID: alice, Username: "Alice Smith", Score: 0, Position: 0},
+				{ID: bob, Username: "bob", Score: 18, Position: 1, IsLocal: true},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := tt.game.WriteGCG(&buf, moves)
+			if err != nil {
+				t.Fatalf("WriteGCG returned error: %v", err)
+			}
+
+			golden := filepath.Join("testdata", tt.name+".gcg")
+			if *update {
+				err = os.WriteFile(golden, buf.Bytes(), 0o644)
+				if err != nil {
+					t.Fatalf("updating golden file: %v", err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("reading golden file: %v", err)
+			}
+			if !bytes.Equal(buf.Bytes(), want) {
+				t.Errorf("WriteGCG wrote\n%s\nwant\n%s", buf.Bytes(), want)
+			}
+		})
+	}
+}
+
+func TestWriteGCGUnknownPlayer(t *testing.T) {
+	g := Game{ID: 42, Players: []Player{{ID: 1, Username: "alice"}}}
+	err := g.WriteGCG(&bytes.Buffer{}, []PlayedMove{{Move: Move{MoveType: MoveTypePass, UserID: 2}}})
+	if err == nil {
+		t.Error("WriteGCG returned no error for a move by a user not playing the game")
+	}
+}
@@ -0,0 +1,200 @@
+package wordfeud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
+)
+
+// GameHistory is the moves of a game recorded by a History.
+type GameHistory struct {
+	Game GameID `json:"game"`
+	// Moves are the recorded moves, in the order they were played.
+	Moves []PlayedMove `json:"moves"`
+	// MoveCount is the number of moves made in the game as of the latest recorded snapshot.
+	MoveCount int `json:"move_count"`
+	// Missed is the number of moves that were made without being recorded, because more than one move was
+	// made between two snapshots, or moves had already been made when the first snapshot was recorded.
+	Missed int `json:"missed"`
+	// Rack is the rack of the local player as of the latest recorded snapshot. It is used to record the
+	// rack a move was played from.
+	Rack []string `json:"rack,omitempty"`
+}
+
+// Complete reports whether every move of the game has been recorded.
+func (h *GameHistory) Complete() bool {
+	return h.Missed == 0
+}
+
+// HistoryStore persists the move histories recorded by a History.
+type HistoryStore interface {
+	// Load returns the stored history of game, or nil if there is none.
+	Load(ctx context.Context, game GameID) (*GameHistory, error)
+	// Save stores the history of a game, replacing any previously stored one.
+	Save(ctx context.Context, history *GameHistory) error
+}
+
+// History records the moves of games from successive snapshots of them. Game only holds the last move
+// made, and the API has no endpoint that lists all moves of a game, so History has to be given a snapshot
+// after every move for the full list of moves to be recorded.
+// Pass it every game returned by Client.Game and Client.Games, and the Game of every MoveResult, for
+// example from the events of a Watcher.
+//
+// History is safe for concurrent use by multiple goroutines.
+type History struct {
+	store HistoryStore
+
+	mu    sync.Mutex
+	games map[GameID]*GameHistory
+}
+
+// NewHistory returns a History that persists the recorded histories to store. If store is nil, histories
+// are only kept in memory.
+func NewHistory(store HistoryStore) *History {
+	return &History{store: store, games: make(map[GameID]*GameHistory)}
+}
+
+// Record records the moves made in g since the previous snapshot of it. Snapshots older than the previous
+// one are ignored. If the history cannot be saved to the store, it is left unchanged, so that the moves
+// are recorded again from the next snapshot.
+func (h *History) Record(ctx context.Context, g *Game) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	prev, err := h.load(ctx, g.ID)
+	if err != nil {
+		return err
+	}
+	recorded := prev != nil
+	gh := &GameHistory{Game: g.ID}
+	if recorded {
+		*gh = *prev
+		gh.Moves = slices.Clone(prev.Moves)
+	}
+	if g.MoveCount < gh.MoveCount {
+		return nil
+	}
+
+	var rack []string
+	for _, p := range g.Players {
+		if p.IsLocal {
+			rack = slices.Clone(p.Rack)
+		}
+	}
+
+	if n := g.MoveCount - gh.MoveCount; n > 0 {
+		if g.LastMove == nil {
+			gh.Missed += n
+		} else {
+			gh.Missed += n - 1
+			m := PlayedMove{Move: *g.LastMove}
+			// The rack the move was played from is only known if it was the only move since the previous
+			// snapshot, and it was made by the local player.
+			if n == 1 && isLocalPlayer(g, m.UserID) {
+				m.Rack = gh.Rack
+			}
+			gh.Moves = append(gh.Moves, m)
+		}
+	} else if recorded && slices.Equal(rack, gh.Rack) {
+		return nil
+	}
+	gh.MoveCount = g.MoveCount
+	gh.Rack = rack
+
+	if h.store != nil {
+		err = h.store.Save(ctx, gh)
+		if err != nil {
+			return fmt.Errorf("saving history: %v", err)
+		}
+	}
+	h.games[g.ID] = gh
+	return nil
+}
+
+// Get returns the recorded history of a game, or nil if no snapshot of it has been recorded.
+func (h *History) Get(ctx context.Context, game GameID) (*GameHistory, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	gh, err := h.load(ctx, game)
+	if err != nil || gh == nil {
+		return nil, err
+	}
+	c := *gh
+	c.Moves = slices.Clone(gh.Moves)
+	c.Rack = slices.Clone(gh.Rack)
+	return &c, nil
+}
+
+// load returns the history of game from memory or, failing that, the store. h.mu must be held.
+func (h *History) load(ctx context.Context, game GameID) (*GameHistory, error) {
+	if gh, ok := h.games[game]; ok {
+		return gh, nil
+	}
+	if h.store == nil {
+		return nil, nil
+	}
+	gh, err := h.store.Load(ctx, game)
+	if err != nil {
+		return nil, fmt.Errorf("loading history: %v", err)
+	}
+	if gh != nil {
+		h.games[game] = gh
+	}
+	return gh, nil
+}
+
+// FileHistoryStore is a HistoryStore that keeps the history of each game in a JSON file in a directory.
+// It is safe for concurrent use by multiple goroutines, but not by multiple processes.
+type FileHistoryStore struct {
+	dir string
+}
+
+// NewFileHistoryStore returns a FileHistoryStore that keeps histories in the directory dir, which is
+// created when the first history is saved.
+func NewFileHistoryStore(dir string) *FileHistoryStore {
+	return &FileHistoryStore{dir: dir}
+}
+
+func (f *FileHistoryStore) path(game GameID) string {
+	return filepath.Join(f.dir, strconv.FormatInt(int64(game), 10)+".json")
+}
+
+func (f *FileHistoryStore) Load(_ context.Context, game GameID) (*GameHistory, error) {
+	b, err := os.ReadFile(f.path(game))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading history file: %v", err)
+	}
+	var gh GameHistory
+	err = json.Unmarshal(b, &gh)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshalling history file: %v", err)
+	}
+	return &gh, nil
+}
+
+func (f *FileHistoryStore) Save(_ context.Context, history *GameHistory) error {
+	b, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshalling history: %v", err)
+	}
+	err = os.MkdirAll(f.dir, 0o755)
+	if err != nil {
+		return fmt.Errorf("creating history directory: %v", err)
+	}
+	err = writeFile(f.path(history.Game), b)
+	if err != nil {
+		return fmt.Errorf("writing history file: %v", err)
+	}
+	return nil
+}
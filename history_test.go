@@ -0,0 +1,183 @@
+package wordfeud
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// snapshot returns a snapshot of game 42 between alice, the local player holding rack, and bob.
+func snapshot(moveCount int, last *Move, rack ...string) *Game {
+	return &Game{ID: 42, IsRunning: true, MoveCount: moveCount, LastMove: last, Players: []Player{
+		{ID: 1, Username: "alice", IsLocal: true, Rack: rack},
+		{ID: 2, Username: "bob"},
+	}}
+}
+
+// memoryHistoryStore is a HistoryStore keeping histories in memory. If err is set, Save fails with it.
+type memoryHistoryStore struct {
+	histories map[GameID]GameHistory
+	err       error
+}
+
+func (m *memoryHistoryStore) Load(_ context.Context, game GameID) (*GameHistory, error) {
+	gh, ok := m.histories[game]
+	if !ok {
+		return nil, nil
+	}
+	return &gh, nil
+}
+
+func (m *memoryHistoryStore) Save(_ context.Context, history *GameHistory) error {
+	if m.err != nil {
+		return m.err
+	}
+	if m.histories == nil {
+		m.histories = make(map[GameID]GameHistory)
+	}
+	m.histories[history.Game] = *history
+	return nil
+}
+
+func TestHistoryRecord(t *testing.T) {
+	byAlice := &Move{MoveType: MoveTypePass, UserID: 1}
+	byBob := &Move{MoveType: MoveTypePass, UserID: 2}
+
+	tests := []struct {
+		name      string
+		snapshots []*Game
+		want      GameHistory
+	}{
+		{
+			name:      "new game",
+			snapshots: []*Game{snapshot(0, nil, "A", "B")},
+			want:      GameHistory{Game: 42, Rack: []string{"A", "B"}},
+		},
+		{
+			name:      "first snapshot after one move",
+			snapshots: []*Game{snapshot(1, byAlice, "C")},
+			// The move is recorded, but the rack it was played from is unknown.
+			want: GameHistory{Game: 42, MoveCount: 1, Rack: []string{"C"}, Moves: []PlayedMove{{Move: *byAlice}}},
+		},
+		{
+			name:      "first snapshot after several moves",
+			snapshots: []*Game{snapshot(3, byBob, "C")},
+			want:      GameHistory{Game: 42, MoveCount: 3, Missed: 2, Rack: []string{"C"}, Moves: []PlayedMove{{Move: *byBob}}},
+		},
+		{
+			name:      "every move",
+			snapshots: []*Game{snapshot(0, nil, "A", "B"), snapshot(1, byAlice, "C", "D"), snapshot(2, byBob, "C", "D")},
+			want: GameHistory{Game: 42, MoveCount: 2, Rack: []string{"C", "D"}, Moves: []PlayedMove{
+				{Move: *byAlice, Rack: []string{"A", "B"}},
+				{Move: *byBob},
+			}},
+		},
+		{
+			name:      "move missed between snapshots",
+			snapshots: []*Game{snapshot(0, nil, "A"), snapshot(2, byBob, "C")},
+			want:      GameHistory{Game: 42, MoveCount: 2, Missed: 1, Rack: []string{"C"}, Moves: []PlayedMove{{Move: *byBob}}},
+		},
+		{
+			// The rack before the move of alice is not known when it was not the only move since the
+			// previous snapshot.
+			name:      "own move after a missed move",
+			snapshots: []*Game{snapshot(0, nil, "A"), snapshot(2, byAlice, "C")},
+			want:      GameHistory{Game: 42, MoveCount: 2, Missed: 1, Rack: []string{"C"}, Moves: []PlayedMove{{Move: *byAlice}}},
+		},
+		{
+			name:      "no last move",
+			snapshots: []*Game{snapshot(0, nil, "A"), snapshot(2, nil, "C")},
+			want:      GameHistory{Game: 42, MoveCount: 2, Missed: 2, Rack: []string{"C"}},
+		},
+		{
+			name:      "repeated snapshot",
+			snapshots: []*Game{snapshot(0, nil, "A"), snapshot(1, byAlice, "C"), snapshot(1, byAlice, "C")},
+			want:      GameHistory{Game: 42, MoveCount: 1, Rack: []string{"C"}, Moves: []PlayedMove{{Move: *byAlice, Rack: []string{"A"}}}},
+		},
+		{
+			name:      "older snapshot",
+			snapshots: []*Game{snapshot(0, nil, "A"), snapshot(2, byBob, "C"), snapshot(1, byAlice, "B")},
+			want:      GameHistory{Game: 42, MoveCount: 2, Missed: 1, Rack: []string{"C"}, Moves: []PlayedMove{{Move: *byBob}}},
+		},
+		{
+			// A changed rack is recorded even if no move was made, and is the rack the next move is played from.
+			name:      "rack changed without a move",
+			snapshots: []*Game{snapshot(0, nil, "A"), snapshot(0, nil, "B"), snapshot(1, byAlice, "C")},
+			want:      GameHistory{Game: 42, MoveCount: 1, Rack: []string{"C"}, Moves: []PlayedMove{{Move: *byAlice, Rack: []string{"B"}}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &memoryHistoryStore{}
+			h := NewHistory(store)
+			ctx := context.Background()
+			for i, g := range tt.snapshots {
+				err := h.Record(ctx, g)
+				if err != nil {
+					t.Fatalf("Record of snapshot %d returned error: %v", i, err)
+				}
+			}
+
+			got, err := h.Get(ctx, 42)
+			if err != nil {
+				t.Fatalf("Get returned error: %v", err)
+			}
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("got history\n%+v\nwant\n%+v", *got, tt.want)
+			}
+			if got.Complete() != (tt.want.Missed == 0) {
+				t.Errorf("Complete() = %v with %d missed moves", got.Complete(), got.Missed)
+			}
+
+			// The history is read back from the store by a new History.
+			stored, err := NewHistory(store).Get(ctx, 42)
+			if err != nil {
+				t.Fatalf("Get from store returned error: %v", err)
+			}
+			if !reflect.DeepEqual(*stored, tt.want) {
+				t.Errorf("got stored history\n%+v\nwant\n%+v", *stored, tt.want)
+			}
+		})
+	}
+}
+
+func TestHistoryRecordSaveFails(t *testing.T) {
+	store := &memoryHistoryStore{}
+	h := NewHistory(store)
+	ctx := context.Background()
+	err := h.Record(ctx, snapshot(0, nil, "A"))
+	if err != nil {
+		t.Fatalf("Record returned error: %v", err)
+	}
+
+	store.err = errors.New("disk full")
+	move := &Move{MoveType: MoveTypePass, UserID: 1}
+	err = h.Record(ctx, snapshot(1, move, "B"))
+	if err == nil {
+		t.Fatal("Record returned no error when saving failed")
+	}
+	gh, err := h.Get(ctx, 42)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if gh.MoveCount != 0 || len(gh.Moves) != 0 {
+		t.Errorf("got history %+v after saving failed, want it unchanged", gh)
+	}
+
+	// The move is recorded from the next snapshot once saving succeeds again.
+	store.err = nil
+	err = h.Record(ctx, snapshot(1, move, "B"))
+	if err != nil {
+		t.Fatalf("Record returned error: %v", err)
+	}
+	gh, err = h.Get(ctx, 42)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	want := GameHistory{Game: 42, MoveCount: 1, Rack: []string{"B"}, Moves: []PlayedMove{{Move: *move, Rack: []string{"A"}}}}
+	if !reflect.DeepEqual(*gh, want) {
+		t.Errorf("got history\n%+v\nwant\n%+v", *gh, want)
+	}
+}
@@ -2,7 +2,9 @@ package wordfeud
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -15,7 +17,7 @@ import (
 //
 // If session is set to anything but the empty string, it will be included in the "Cookie" header of the request.
 // If body is not nil, it will be marshalled to JSON and sent as the request body.
-func roundtrip[C any](c *Client, method string, path string, session SessionID, body any) (*C, error) {
+func roundtrip[C any](ctx context.Context, c *Client, method string, path string, session SessionID, body any) (*C, error) {
 	var b []byte
 	if body != nil {
 		var err error
@@ -24,7 +26,7 @@ func roundtrip[C any](c *Client, method string, path string, session SessionID,
 			return nil, fmt.Errorf("marshalling request body: %v", err)
 		}
 	}
-	res, err := c.request(method, path, session, b)
+	res, err := c.request(ctx, method, path, session, b)
 	if err != nil {
 		return nil, err
 	}
@@ -46,7 +48,8 @@ type response struct {
 }
 
 // request executes an HTTP request to path using method. It reads the response body in full and
-// returns a response.
+// returns a response. The request is bound to ctx, and if ctx is cancelled or its deadline expires, the
+// returned error wraps the context error.
 //
 // If session is set to anything but the empty string, it will be included in the Cookie header of the request.
 // If body is not nil, it will be marshalled to JSON and sent as the request body.
@@ -55,10 +58,34 @@ type response struct {
 // To alleviate this, part of the response body is eagerly parsed in the search of errors, even if
 // the HTTP status code is 200. This method will return a non-nil error if the response body "status"
 // field is equal to "error" (or if the status code is not 200).
-func (c *Client) request(method string, path string, session SessionID, body []byte) (*response, error) {
+//
+// Failed requests are retried according to the retry policy of the client, if any. Every attempt waits
+// for the rate limits of the client.
+func (c *Client) request(ctx context.Context, method string, path string, session SessionID, body []byte) (*response, error) {
+	for attempt := 1; ; attempt++ {
+		err := c.waitForLimits(ctx, session)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := c.send(ctx, method, path, session, body)
+		var t *transientError
+		if err == nil || !errors.As(err, &t) || ctx.Err() != nil || !c.retry.allows(method, attempt) {
+			return res, err
+		}
+
+		err = c.retry.wait(ctx, attempt)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// send executes a single attempt of request.
+func (c *Client) send(ctx context.Context, method string, path string, session SessionID, body []byte) (*response, error) {
 	// Trailing slash is required.
 	url := fmt.Sprintf("%s/%s/", c.baseURL, strings.Trim(path, "/"))
-	req, err := http.NewRequest(method, url, bytes.NewBuffer(body))
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewBuffer(body))
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %v", err)
 	}
@@ -72,7 +99,7 @@ func (c *Client) request(method string, path string, session SessionID, body []b
 
 	res, err := c.cl.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("sending request: %v", err)
+		return nil, &transientError{fmt.Errorf("sending request: %w", err)}
 	}
 	defer func(body io.ReadCloser) {
 		err := body.Close()
@@ -84,7 +111,7 @@ func (c *Client) request(method string, path string, session SessionID, body []b
 	var b bytes.Buffer
 	_, err = io.Copy(&b, res.Body)
 	if err != nil {
-		return nil, fmt.Errorf("reading response body: %v", err)
+		return nil, fmt.Errorf("reading response body: %w", err)
 	}
 	bodyBytes := b.Bytes()
 
@@ -92,13 +119,17 @@ func (c *Client) request(method string, path string, session SessionID, body []b
 		if res.StatusCode == http.StatusOK {
 			return &response{Content: nil, Header: res.Header}, nil
 		}
-		return nil, fmt.Errorf("status code %d (no body)", res.StatusCode)
+		err = fmt.Errorf("status code %d (no body)", res.StatusCode)
+		if res.StatusCode >= http.StatusInternalServerError {
+			return nil, &transientError{err}
+		}
+		return nil, err
 	}
 
 	// Internal Server Errors are sent as text/html instead of JSON (which is sent as text/plain(!))
 	// so in that case we bail here and include the body in the error message.
 	if res.StatusCode >= http.StatusInternalServerError {
-		return nil, fmt.Errorf("status code %d: %s", res.StatusCode, bodyBytes)
+		return nil, &transientError{fmt.Errorf("status code %d: %s", res.StatusCode, bodyBytes)}
 	}
 
 	var responseBody struct {
@@ -111,12 +142,15 @@ func (c *Client) request(method string, path string, session SessionID, body []b
 		return nil, fmt.Errorf("unmarshalling response body (status code %d): %v", res.StatusCode, err)
 	}
 	if responseBody.Status == "error" {
-		var e *apiError
+		var e APIError
 		err = json.Unmarshal(responseBody.Content, &e)
 		if err != nil {
 			return nil, fmt.Errorf("unmarshalling error response (status code %d): %v", res.StatusCode, err)
 		}
-		return nil, convertToSentinel(e)
+		e.StatusCode = res.StatusCode
+		e.Method = method
+		e.Path = path
+		return nil, &e
 	}
 
 	r := &response{
@@ -126,19 +160,53 @@ func (c *Client) request(method string, path string, session SessionID, body []b
 	return r, nil
 }
 
-type apiError struct {
-	Type    string `json:"type"`
+// transientError is an error that may go away if the request is retried, such as a network error or an
+// Internal Server Error.
+type transientError struct {
+	err error
+}
+
+func (e *transientError) Error() string {
+	return e.err.Error()
+}
+
+func (e *transientError) Unwrap() error {
+	return e.err
+}
+
+// APIError is an error reported by the Wordfeud API. APIErrors with a known Type match the corresponding
+// sentinel error, so that for example errors.Is(err, ErrIllegalWord) reports true for an APIError with
+// the Type "illegal_word".
+type APIError struct {
+	// Type is the type of error, such as "illegal_word".
+	Type string `json:"type"`
+	// Message is a human-readable description of the error. It is often empty.
 	Message string `json:"message"`
+	// StatusCode is the HTTP status code of the response, which is usually 200 even for errors.
+	StatusCode int `json:"-"`
+	// Method is the HTTP method of the request.
+	Method string `json:"-"`
+	// Path is the API path of the request, such as "/game/123/move".
+	Path string `json:"-"`
 }
 
-func (e *apiError) Error() string {
+func (e *APIError) Error() string {
 	msg := e.Type
 	if e.Message != "" {
 		msg = fmt.Sprintf("%s: %s", msg, e.Message)
 	}
+	if e.Method != "" {
+		msg = fmt.Sprintf("%s (%s %s, status code %d)", msg, e.Method, e.Path, e.StatusCode)
+	}
 	return msg
 }
 
+// Is reports whether target is the sentinel error corresponding to the type of e.
+func (e *APIError) Is(target error) bool {
+	s, ok := sentinels[e.Type]
+	return ok && s == target
+}
+
 var cookieRegexp = regexp.MustCompile(`sessionid=(.+?)(?:;|$)`)
 
 func extractSessionID(r *response) (SessionID, error) {
@@ -0,0 +1,183 @@
+package wordfeud
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"strconv"
+)
+
+// The layout of board images, in pixels.
+const (
+	imageSquareSize = 48
+	imageGap        = 2
+	imageMargin     = 32
+	imageSize       = imageMargin + BoardSize*imageSquareSize + imageMargin/2
+)
+
+// The colors of board images.
+var (
+	colorBackground  = color.RGBA{0x2b, 0x2f, 0x36, 0xff}
+	colorLabel       = color.RGBA{0xd0, 0xd4, 0xda, 0xff}
+	colorSquare      = color.RGBA{0xd9, 0xd4, 0xc7, 0xff}
+	colorDL          = color.RGBA{0x5b, 0x9b, 0xd5, 0xff}
+	colorTL          = color.RGBA{0x1f, 0x4e, 0x9a, 0xff}
+	colorDW          = color.RGBA{0xe3, 0x9b, 0x3b, 0xff}
+	colorTW          = color.RGBA{0xb5, 0x41, 0x2f, 0xff}
+	colorPremiumText = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	colorTile        = color.RGBA{0xf3, 0xe2, 0xb3, 0xff}
+	colorHighlight   = color.RGBA{0xff, 0xd5, 0x4f, 0xff}
+	colorLetter      = color.RGBA{0x2b, 0x2b, 0x2b, 0xff}
+	colorBlank       = color.RGBA{0xb5, 0x41, 0x2f, 0xff}
+)
+
+// imageSquare is a square of a board image.
+type imageSquare struct {
+	// rect is the area of the square, excluding the gap between squares.
+	rect image.Rectangle
+	fill color.RGBA
+	// label is the letter of the tile on the square, or the name of the premium square if it is empty.
+	label string
+	// points is the value of the tile on the square, or zero if it has none.
+	points int
+	tile   bool
+	blank  bool
+}
+
+// squares lays out the squares of b for drawing on an image.
+func (r *renderer) squares(b *BoardState, ruleset *Ruleset) []imageSquare {
+	squares := make([]imageSquare, 0, BoardSize*BoardSize)
+	for row := range BoardSize {
+		for column := range BoardSize {
+			x, y := imageMargin+column*imageSquareSize, imageMargin+row*imageSquareSize
+			s := imageSquare{
+				rect: image.Rect(x, y, x+imageSquareSize-imageGap, y+imageSquareSize-imageGap),
+				fill: colorSquare,
+			}
+
+			if t, ok := b.Tile(column, row); ok {
+				s.tile, s.blank, s.label = true, t.Blank, t.Letter
+				s.fill = colorTile
+				if r.highlight[[2]int{column, row}] {
+					s.fill = colorHighlight
+				}
+				if ruleset != nil {
+					s.points = ruleset.Points(t.Letter, t.Blank)
+				}
+			} else {
+				switch b.Grid.Square(column, row) {
+				case SquareDL:
+					s.fill, s.label = colorDL, "DL"
+				case SquareTL:
+					s.fill, s.label = colorTL, "TL"
+				case SquareDW:
+					s.fill, s.label = colorDW, "DW"
+				case SquareTW:
+					s.fill, s.label = colorTW, "TW"
+				}
+			}
+			squares = append(squares, s)
+		}
+	}
+	return squares
+}
+
+// Image draws b as an image, with colored premium squares and tiles showing their letter and, if ruleset
+// is not nil, their point value. Blanks are drawn with a differently colored letter. Columns and rows are
+// labelled as in the notation accepted by ParseMove. Tiles highlighted by WithHighlight or WithLastMove
+// are drawn in a different color, and WithUnicode has no effect.
+func (b *BoardState) Image(ruleset *Ruleset, opts ...RenderOption) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, imageSize, imageSize))
+	draw.Draw(img, img.Bounds(), image.NewUniform(colorBackground), image.Point{}, draw.Src)
+
+	const labelScale = 2
+	for i := range BoardSize {
+		column := string(rune('A' + i))
+		x := imageMargin + i*imageSquareSize + (imageSquareSize-imageGap-textWidth(column, labelScale))/2
+		drawText(img, x, (imageMargin-fontHeight*labelScale)/2, column, labelScale, colorLabel)
+
+		row := strconv.Itoa(i + 1)
+		y := imageMargin + i*imageSquareSize + (imageSquareSize-imageGap-fontHeight*labelScale)/2
+		drawText(img, imageMargin-4-textWidth(row, labelScale), y, row, labelScale, colorLabel)
+	}
+
+	for _, s := range newRenderer(opts).squares(b, ruleset) {
+		draw.Draw(img, s.rect, image.NewUniform(s.fill), image.Point{}, draw.Src)
+		size := s.rect.Dx()
+		if !s.tile {
+			if s.label != "" {
+				x := s.rect.Min.X + (size-textWidth(s.label, 2))/2
+				y := s.rect.Min.Y + (size-fontHeight*2)/2
+				drawText(img, x, y, s.label, 2, colorPremiumText)
+			}
+			continue
+		}
+
+		c := colorLetter
+		if s.blank {
+			c = colorBlank
+		}
+		// The letter is drawn left of center to make room for the points in the bottom right corner,
+		// and low enough to fit diacritics above it.
+		drawText(img, s.rect.Min.X+6, s.rect.Min.Y+15, s.label, 3, c)
+		if s.points > 0 {
+			points := strconv.Itoa(s.points)
+			drawText(img, s.rect.Max.X-3-textWidth(points, 2), s.rect.Max.Y-3-fontHeight*2, points, 2, colorLetter)
+		}
+	}
+	return img
+}
+
+// RenderPNG writes b to w as a PNG image, as drawn by Image.
+func (b *BoardState) RenderPNG(w io.Writer, ruleset *Ruleset, opts ...RenderOption) error {
+	return png.Encode(w, b.Image(ruleset, opts...))
+}
+
+// RenderSVG writes b to w as an SVG image, with the same layout as the images drawn by Image.
+func (b *BoardState) RenderSVG(w io.Writer, ruleset *Ruleset, opts ...RenderOption) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %[1]d %[2]d" font-family="Helvetica, Arial, sans-serif" font-weight="bold">`+"\n", imageSize, imageSize)
+	fmt.Fprintf(bw, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", hexColor(colorBackground))
+
+	half := (imageSquareSize - imageGap) / 2
+	for i := range BoardSize {
+		fmt.Fprintf(bw, `<text x="%d" y="%d" fill="%s" font-size="14" text-anchor="middle">%c</text>`+"\n",
+			imageMargin+i*imageSquareSize+half, imageMargin/2+5, hexColor(colorLabel), 'A'+i)
+		fmt.Fprintf(bw, `<text x="%d" y="%d" fill="%s" font-size="14" text-anchor="end">%d</text>`+"\n",
+			imageMargin-4, imageMargin+i*imageSquareSize+half+5, hexColor(colorLabel), i+1)
+	}
+
+	for _, s := range newRenderer(opts).squares(b, ruleset) {
+		x, y, size := s.rect.Min.X, s.rect.Min.Y, s.rect.Dx()
+		fmt.Fprintf(bw, `<rect x="%d" y="%d" width="%d" height="%[3]d" rx="3" fill="%s"/>`+"\n", x, y, size, hexColor(s.fill))
+		if !s.tile {
+			if s.label != "" {
+				fmt.Fprintf(bw, `<text x="%d" y="%d" fill="%s" font-size="13" text-anchor="middle">%s</text>`+"\n",
+					x+size/2, y+size/2+5, hexColor(colorPremiumText), s.label)
+			}
+			continue
+		}
+
+		c := colorLetter
+		if s.blank {
+			c = colorBlank
+		}
+		fmt.Fprintf(bw, `<text x="%d" y="%d" fill="%s" font-size="26" text-anchor="middle">%s</text>`+"\n",
+			x+size/2-3, y+size/2+9, hexColor(c), html.EscapeString(s.label))
+		if s.points > 0 {
+			fmt.Fprintf(bw, `<text x="%d" y="%d" fill="%s" font-size="11" text-anchor="end">%d</text>`+"\n",
+				x+size-3, y+size-4, hexColor(colorLetter), s.points)
+		}
+	}
+	fmt.Fprintln(bw, "</svg>")
+	return bw.Flush()
+}
+
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
@@ -0,0 +1,394 @@
+package wordfeud
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Lexicon is a compact, read-only word list stored as a DAWG (directed acyclic word graph). Compared to
+// a Dictionary it uses a fraction of the memory, can be serialized to and loaded from a binary format
+// quickly, and supports the prefix queries needed for move generation. It is safe for concurrent use by
+// multiple goroutines.
+type Lexicon struct {
+	ruleset RulesetID
+	letters []rune
+	index   alphabet
+	count   int
+	root    uint32
+	// The edges of node n are edgeLetters[nodes[n]:nodes[n+1]] and edgeTargets[nodes[n]:nodes[n+1]],
+	// sorted by letter.
+	nodes       []uint32
+	terminal    []byte
+	edgeLetters []byte
+	edgeTargets []uint32
+}
+
+// NewLexicon builds a Lexicon containing all words in d.
+func NewLexicon(d *Dictionary) *Lexicon {
+	l := &Lexicon{
+		ruleset: d.ruleset,
+		letters: make([]rune, len(d.alphabet)+1),
+		index:   d.alphabet,
+	}
+	for c, i := range d.alphabet {
+		l.letters[i] = c
+	}
+
+	words := make([][]byte, 0, len(d.words))
+	for w := range d.words {
+		words = append(words, l.encode(w))
+	}
+	slices.SortFunc(words, func(a, b []byte) int {
+		return strings.Compare(string(a), string(b))
+	})
+	l.build(words)
+	return l
+}
+
+// Ruleset returns the ruleset the lexicon was built for.
+func (l *Lexicon) Ruleset() RulesetID {
+	return l.ruleset
+}
+
+// Len returns the number of words in the lexicon.
+func (l *Lexicon) Len() int {
+	return l.count
+}
+
+// Contains reports whether word is in the lexicon.
+func (l *Lexicon) Contains(word string) bool {
+	n, ok := l.walk(word)
+	return ok && l.isTerminal(n)
+}
+
+// HasPrefix reports whether any word in the lexicon starts with prefix.
+func (l *Lexicon) HasPrefix(prefix string) bool {
+	_, ok := l.walk(prefix)
+	return ok
+}
+
+// Suffixes returns all strings s such that prefix+s is a word in the lexicon, in alphabetical order.
+// If prefix itself is a word, the empty string is included.
+func (l *Lexicon) Suffixes(prefix string) []string {
+	n, ok := l.walk(prefix)
+	if !ok {
+		return nil
+	}
+
+	var suffixes []string
+	var buf []rune
+	var visit func(n uint32)
+	visit = func(n uint32) {
+		if l.isTerminal(n) {
+			suffixes = append(suffixes, string(buf))
+		}
+		for e := l.nodes[n]; e < l.nodes[n+1]; e++ {
+			buf = append(buf, l.letters[l.edgeLetters[e]])
+			visit(l.edgeTargets[e])
+			buf = buf[:len(buf)-1]
+		}
+	}
+	visit(n)
+	return suffixes
+}
+
+// Check checks that move is legal to play on b and that every word it forms is in the lexicon.
+//
+// If the move is illegal, the returned error satisfies errors.Is(err, ErrIllegalMove). If it forms words
+// that are not in the lexicon, the returned error lists them and satisfies errors.Is(err, ErrIllegalWord).
+func (l *Lexicon) Check(b *BoardState, move []Placement) error {
+	return checkWords(b, move, l.Contains)
+}
+
+// walk follows the letters of s from the root and returns the node it ends up in. The second return value
+// is false if there is no such path.
+func (l *Lexicon) walk(s string) (uint32, bool) {
+	s, ok := l.index.normalize(s)
+	if !ok {
+		return 0, false
+	}
+
+	n := l.root
+	for _, c := range s {
+		n, ok = l.child(n, l.index[c])
+		if !ok {
+			return 0, false
+		}
+	}
+	return n, true
+}
+
+// child returns the node reached by following the edge labelled letter from n. The second return value
+// is false if there is no such edge.
+func (l *Lexicon) child(n uint32, letter byte) (uint32, bool) {
+	edges := l.edgeLetters[l.nodes[n]:l.nodes[n+1]]
+	i, ok := slices.BinarySearch(edges, letter)
+	if !ok {
+		return 0, false
+	}
+	return l.edgeTargets[l.nodes[n]+uint32(i)], true
+}
+
+func (l *Lexicon) isTerminal(n uint32) bool {
+	return l.terminal[n/8]&(1<<(n%8)) != 0
+}
+
+// encode converts a normalized word to letter indices.
+func (l *Lexicon) encode(word string) []byte {
+	b := make([]byte, 0, len(word))
+	for _, c := range word {
+		b = append(b, l.index[c])
+	}
+	return b
+}
+
+// buildNode is a node of a DAWG under construction.
+type buildNode struct {
+	id       uint32
+	terminal bool
+	letters  []byte
+	children []*buildNode
+}
+
+// key returns a string identifying the right language of n, which is the same for all equivalent nodes.
+// All children of n must already be registered.
+func (n *buildNode) key() string {
+	b := make([]byte, 0, 1+len(n.letters)*5)
+	if n.terminal {
+		b = append(b, 1)
+	} else {
+		b = append(b, 0)
+	}
+	for i, c := range n.letters {
+		b = append(b, c)
+		b = binary.LittleEndian.AppendUint32(b, n.children[i].id)
+	}
+	return string(b)
+}
+
+// build constructs a minimal DAWG from words, which must be sorted and may contain duplicates, using the
+// incremental algorithm described by Daciuk et al. in "Incremental Construction of Minimal Acyclic Finite
+// State Automata".
+func (l *Lexicon) build(words [][]byte) {
+	root := &buildNode{}
+	register := make(map[string]*buildNode)
+	var registered []*buildNode
+
+	var replaceOrRegister func(n *buildNode)
+	replaceOrRegister = func(n *buildNode) {
+		last := len(n.children) - 1
+		child := n.children[last]
+		if len(child.children) > 0 {
+			replaceOrRegister(child)
+		}
+		k := child.key()
+		if q, ok := register[k]; ok {
+			n.children[last] = q
+			return
+		}
+		child.id = uint32(len(registered))
+		register[k] = child
+		registered = append(registered, child)
+	}
+
+	var prev []byte
+	for _, w := range words {
+		if string(w) == string(prev) {
+			continue
+		}
+		l.count++
+
+		n := root
+		common := 0
+		for common < len(w) && common < len(prev) && w[common] == prev[common] {
+			n = n.children[len(n.children)-1]
+			common++
+		}
+		if len(n.children) > 0 {
+			replaceOrRegister(n)
+		}
+		for _, c := range w[common:] {
+			child := &buildNode{}
+			n.letters = append(n.letters, c)
+			n.children = append(n.children, child)
+			n = child
+		}
+		n.terminal = true
+		prev = w
+	}
+	if len(root.children) > 0 {
+		replaceOrRegister(root)
+	}
+	root.id = uint32(len(registered))
+	registered = append(registered, root)
+
+	l.root = root.id
+	l.nodes = make([]uint32, 0, len(registered)+1)
+	l.terminal = make([]byte, (len(registered)+7)/8)
+	for _, n := range registered {
+		l.nodes = append(l.nodes, uint32(len(l.edgeLetters)))
+		if n.terminal {
+			l.terminal[n.id/8] |= 1 << (n.id % 8)
+		}
+		l.edgeLetters = append(l.edgeLetters, n.letters...)
+		for _, c := range n.children {
+			l.edgeTargets = append(l.edgeTargets, c.id)
+		}
+	}
+	l.nodes = append(l.nodes, uint32(len(l.edgeLetters)))
+}
+
+// lexiconMagic identifies the binary lexicon format, followed by the format version.
+var lexiconMagic = [5]byte{'W', 'F', 'L', 'X', 1}
+
+// lexiconHeader is the fixed size part of the binary lexicon format, which is followed by the alphabet
+// as UTF-8, the node offsets, the terminal bitset, the edge letters and the edge targets. Nodes are
+// numbered so that every edge leads to a node with a lower number than the one it leaves.
+type lexiconHeader struct {
+	Magic         [5]byte
+	Ruleset       uint8
+	AlphabetBytes uint32
+	Words         uint32
+	Root          uint32
+	Nodes         uint32
+	Edges         uint32
+}
+
+// WriteTo writes l to w in a binary format that can be read by ReadLexicon.
+func (l *Lexicon) WriteTo(w io.Writer) (int64, error) {
+	letters := string(l.letters[1:])
+	h := lexiconHeader{
+		Magic:         lexiconMagic,
+		Ruleset:       uint8(l.ruleset),
+		AlphabetBytes: uint32(len(letters)),
+		Words:         uint32(l.count),
+		Root:          l.root,
+		Nodes:         uint32(len(l.nodes) - 1),
+		Edges:         uint32(len(l.edgeLetters)),
+	}
+
+	cw := &countingWriter{w: w}
+	bw := bufio.NewWriter(cw)
+	for _, v := range []any{h, []byte(letters), l.nodes, l.terminal, l.edgeLetters, l.edgeTargets} {
+		err := binary.Write(bw, binary.LittleEndian, v)
+		if err != nil {
+			return cw.n, fmt.Errorf("writing lexicon: %v", err)
+		}
+	}
+	err := bw.Flush()
+	if err != nil {
+		return cw.n, fmt.Errorf("writing lexicon: %v", err)
+	}
+	return cw.n, nil
+}
+
+// ReadLexicon reads a Lexicon written by Lexicon.WriteTo from r. The input is validated, so that a corrupt
+// lexicon is reported as an error rather than causing a panic later on.
+func ReadLexicon(r io.Reader) (*Lexicon, error) {
+	br := bufio.NewReader(r)
+
+	var h lexiconHeader
+	err := binary.Read(br, binary.LittleEndian, &h)
+	if err != nil {
+		return nil, fmt.Errorf("reading lexicon header: %v", err)
+	}
+	if h.Magic != lexiconMagic {
+		return nil, errors.New("not a lexicon, or unsupported lexicon version")
+	}
+	if h.AlphabetBytes > maxLexiconLetters*utf8.UTFMax || h.Nodes == 0 || h.Root >= h.Nodes {
+		return nil, errCorruptLexicon
+	}
+
+	l := &Lexicon{
+		ruleset: RulesetID(h.Ruleset),
+		count:   int(h.Words),
+		root:    h.Root,
+	}
+	// The slices are read in chunks rather than allocated up front, so that a corrupt header cannot make
+	// us allocate more memory than the input actually holds.
+	letters, err := readChunked[byte](br, int(h.AlphabetBytes))
+	if err == nil {
+		l.nodes, err = readChunked[uint32](br, int(h.Nodes)+1)
+	}
+	if err == nil {
+		l.terminal, err = readChunked[byte](br, (int(h.Nodes)+7)/8)
+	}
+	if err == nil {
+		l.edgeLetters, err = readChunked[byte](br, int(h.Edges))
+	}
+	if err == nil {
+		l.edgeTargets, err = readChunked[uint32](br, int(h.Edges))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading lexicon: %v", err)
+	}
+
+	if !utf8.Valid(letters) {
+		return nil, errCorruptLexicon
+	}
+	l.letters = append([]rune{0}, []rune(string(letters))...)
+	if len(l.letters)-1 > maxLexiconLetters {
+		return nil, errCorruptLexicon
+	}
+	l.index = make(alphabet, len(l.letters)-1)
+	for i, c := range l.letters[1:] {
+		l.index[c] = byte(i + 1)
+	}
+
+	if l.nodes[h.Nodes] != h.Edges {
+		return nil, errCorruptLexicon
+	}
+	for n := uint32(0); n < h.Nodes; n++ {
+		lo, hi := l.nodes[n], l.nodes[n+1]
+		if lo > hi || hi > h.Edges {
+			return nil, errCorruptLexicon
+		}
+		for e := lo; e < hi; e++ {
+			// Letters must be sorted for child to find them, and edges must lead to lower numbered nodes
+			// for the graph to be acyclic.
+			c := l.edgeLetters[e]
+			if c == 0 || int(c) >= len(l.letters) || (e > lo && c <= l.edgeLetters[e-1]) || l.edgeTargets[e] >= n {
+				return nil, errCorruptLexicon
+			}
+		}
+	}
+	return l, nil
+}
+
+// maxLexiconLetters is the maximum number of letters in the alphabet of a lexicon, which is limited by
+// the letter sets used for move generation.
+const maxLexiconLetters = 63
+
+var errCorruptLexicon = errors.New("corrupt lexicon")
+
+// readChunked reads n little-endian values of type T from r, allocating memory as the values are read.
+func readChunked[T byte | uint32](r io.Reader, n int) ([]T, error) {
+	const chunk = 1 << 16
+	s := make([]T, 0, min(n, chunk))
+	for len(s) < n {
+		c := make([]T, min(n-len(s), chunk))
+		err := binary.Read(r, binary.LittleEndian, c)
+		if err != nil {
+			return nil, err
+		}
+		s = append(s, c...)
+	}
+	return s, nil
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,232 @@
+package wordfeud
+
+import (
+	"bytes"
+	"encoding/binary"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const testWords = `
+# A small word list.
+cat
+cats
+car
+cart
+carts
+act
+acts
+at
+as
+ta
+tas
+scat
+cast
+dog
+dogs
+do
+zebra
+Øre
+`
+
+func testLexicon(t *testing.T) *Lexicon {
+	t.Helper()
+	d, err := LoadDictionary(RuleSetEnglish, strings.NewReader(testWords))
+	if err != nil {
+		t.Fatalf("LoadDictionary returned error: %v", err)
+	}
+	return NewLexicon(d)
+}
+
+func TestLexicon(t *testing.T) {
+	l := testLexicon(t)
+
+	// ØRE is skipped, since Ø is not part of the English alphabet.
+	if l.Len() != 17 {
+		t.Errorf("got %d words, want 17", l.Len())
+	}
+	for _, w := range []string{"CAT", "cats", "Carts", "AT", "DO", "ZEBRA"} {
+		if !l.Contains(w) {
+			t.Errorf("Contains(%q) = false, want true", w)
+		}
+	}
+	for _, w := range []string{"", "C", "CA", "CATSS", "DOGE", "ØRE", "ORE"} {
+		if l.Contains(w) {
+			t.Errorf("Contains(%q) = true, want false", w)
+		}
+	}
+
+	for _, p := range []string{"", "C", "CAR", "ZEB"} {
+		if !l.HasPrefix(p) {
+			t.Errorf("HasPrefix(%q) = false, want true", p)
+		}
+	}
+	for _, p := range []string{"B", "CATZ", "Ø"} {
+		if l.HasPrefix(p) {
+			t.Errorf("HasPrefix(%q) = true, want false", p)
+		}
+	}
+
+	got := l.Suffixes("CA")
+	want := []string{"R", "RT", "RTS", "ST", "T", "TS"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Suffixes(\"CA\") = %q, want %q", got, want)
+	}
+	if got := l.Suffixes("DOG"); !slices.Equal(got, []string{"", "S"}) {
+		t.Errorf("Suffixes(\"DOG\") = %q, want [\"\" \"S\"]", got)
+	}
+	if got := l.Suffixes("X"); got != nil {
+		t.Errorf("Suffixes(\"X\") = %q, want nil", got)
+	}
+}
+
+func TestLexiconRoundTrip(t *testing.T) {
+	l := testLexicon(t)
+
+	var buf bytes.Buffer
+	n, err := l.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("WriteTo returned error: %v", err)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("WriteTo reported %d bytes, wrote %d", n, buf.Len())
+	}
+
+	r, err := ReadLexicon(&buf)
+	if err != nil {
+		t.Fatalf("ReadLexicon returned error: %v", err)
+	}
+	if r.Ruleset() != l.Ruleset() {
+		t.Errorf("got ruleset %d, want %d", r.Ruleset(), l.Ruleset())
+	}
+	if r.Len() != l.Len() {
+		t.Errorf("got %d words, want %d", r.Len(), l.Len())
+	}
+	if got, want := r.Suffixes(""), l.Suffixes(""); !slices.Equal(got, want) {
+		t.Errorf("read lexicon contains %q, want %q", got, want)
+	}
+}
+
+// lexiconSections returns the offsets of the sections of a serialized lexicon following the header.
+func lexiconSections(t *testing.T, b []byte) (h lexiconHeader, nodes, edgeLetters, edgeTargets int) {
+	t.Helper()
+	err := binary.Read(bytes.NewReader(b), binary.LittleEndian, &h)
+	if err != nil {
+		t.Fatalf("reading header: %v", err)
+	}
+	nodes = binary.Size(h) + int(h.AlphabetBytes)
+	edgeLetters = nodes + 4*int(h.Nodes+1) + int(h.Nodes+7)/8
+	edgeTargets = edgeLetters + int(h.Edges)
+	return h, nodes, edgeLetters, edgeTargets
+}
+
+func TestReadLexiconCorrupt(t *testing.T) {
+	var buf bytes.Buffer
+	_, err := testLexicon(t).WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("WriteTo returned error: %v", err)
+	}
+	valid := buf.Bytes()
+	h, nodes, edgeLetters, edgeTargets := lexiconSections(t, valid)
+	headerSize := binary.Size(h)
+
+	tests := []struct {
+		name    string
+		corrupt func(b []byte) []byte
+	}{
+		{"bad magic", func(b []byte) []byte {
+			b[0] = 'X'
+			return b
+		}},
+		{"unsupported version", func(b []byte) []byte {
+			b[4] = 2
+			return b
+		}},
+		{"huge alphabet", func(b []byte) []byte {
+			binary.LittleEndian.PutUint32(b[6:], 1<<31)
+			return b
+		}},
+		{"huge node count", func(b []byte) []byte {
+			binary.LittleEndian.PutUint32(b[18:], 1<<31)
+			return b
+		}},
+		{"huge edge count", func(b []byte) []byte {
+			binary.LittleEndian.PutUint32(b[22:], 1<<31)
+			return b
+		}},
+		{"no nodes", func(b []byte) []byte {
+			binary.LittleEndian.PutUint32(b[18:], 0)
+			return b
+		}},
+		{"root out of range", func(b []byte) []byte {
+			binary.LittleEndian.PutUint32(b[14:], h.Nodes)
+			return b
+		}},
+		{"decreasing node offsets", func(b []byte) []byte {
+			binary.LittleEndian.PutUint32(b[nodes+4:], h.Edges)
+			return b
+		}},
+		{"node offset out of range", func(b []byte) []byte {
+			binary.LittleEndian.PutUint32(b[nodes+4:], h.Edges+100)
+			return b
+		}},
+		{"edge letter out of range", func(b []byte) []byte {
+			b[edgeLetters] = 200
+			return b
+		}},
+		{"edge letter zero", func(b []byte) []byte {
+			b[edgeLetters] = 0
+			return b
+		}},
+		{"edge target out of range", func(b []byte) []byte {
+			binary.LittleEndian.PutUint32(b[edgeTargets:], h.Nodes)
+			return b
+		}},
+		{"cycle", func(b []byte) []byte {
+			// The last edge belongs to the root, which is the last node. Make it lead back to the root.
+			binary.LittleEndian.PutUint32(b[len(b)-4:], h.Root)
+			return b
+		}},
+		{"truncated header", func(b []byte) []byte {
+			return b[:headerSize-1]
+		}},
+		{"truncated body", func(b []byte) []byte {
+			return b[:len(b)-1]
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadLexicon(bytes.NewReader(tt.corrupt(bytes.Clone(valid))))
+			if err == nil {
+				t.Fatal("ReadLexicon returned no error")
+			}
+		})
+	}
+}
+
+// TestReadLexiconBitFlips checks that flipping any single bit of a serialized lexicon either results in an
+// error or in a lexicon that can be queried without panicking.
+func TestReadLexiconBitFlips(t *testing.T) {
+	var buf bytes.Buffer
+	_, err := testLexicon(t).WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("WriteTo returned error: %v", err)
+	}
+	valid := buf.Bytes()
+
+	for i := range valid {
+		for bit := 0; bit < 8; bit++ {
+			b := bytes.Clone(valid)
+			b[i] ^= 1 << bit
+			l, err := ReadLexicon(bytes.NewReader(b))
+			if err != nil {
+				continue
+			}
+			l.Suffixes("")
+			l.Contains("CATS")
+			l.HasPrefix("DO")
+		}
+	}
+}
@@ -0,0 +1,307 @@
+package wordfeud
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Candidate is a legal move generated by GenerateMoves.
+type Candidate struct {
+	Move  []Placement
+	Score *Score
+}
+
+// GenerateMoves returns every legal move that can be played on b using the tiles in rack, where words are
+// checked against lexicon and scored using the letter values of ruleset. Blank tiles in rack are
+// represented by BlankTile. The moves are ready to be passed to Client.Move, and are sorted by score
+// with the highest scoring move first.
+//
+// Moves are generated using the algorithm described by Appel and Jacobson in "The World's Fastest
+// Scrabble Program".
+func GenerateMoves(b *BoardState, rack []string, lexicon *Lexicon, ruleset *Ruleset) ([]Candidate, error) {
+	g := &generator{
+		board:   b,
+		lexicon: lexicon,
+		seen:    make(map[string]bool),
+	}
+	for _, t := range rack {
+		if t == BlankTile {
+			g.blanks++
+			continue
+		}
+		l, ok := lexicon.index.normalize(t)
+		if !ok || len([]rune(l)) != 1 {
+			return nil, fmt.Errorf("%w: %q is not a letter of the lexicon alphabet", ErrIllegalTiles, t)
+		}
+		g.rack[lexicon.index[[]rune(l)[0]]]++
+	}
+
+	for _, d := range []Direction{Across, Down} {
+		g.generate(d)
+	}
+
+	candidates := make([]Candidate, 0, len(g.moves))
+	for _, m := range g.moves {
+		s, err := b.Score(m, ruleset)
+		if err != nil {
+			// Should never happen, since the generator only produces legal moves.
+			return nil, fmt.Errorf("generated illegal move: %v", err)
+		}
+		candidates = append(candidates, Candidate{Move: m, Score: s})
+	}
+	slices.SortStableFunc(candidates, func(a, b Candidate) int {
+		return b.Score.Total - a.Score.Total
+	})
+	return candidates, nil
+}
+
+// letterSet is a set of letter indices of a lexicon alphabet.
+type letterSet uint64
+
+const allLetters = ^letterSet(0)
+
+func (s letterSet) has(letter byte) bool {
+	return s&(1<<letter) != 0
+}
+
+type generator struct {
+	board   *BoardState
+	lexicon *Lexicon
+	rack    [64]int
+	blanks  int
+
+	// The state of the line currently being generated for.
+	direction   Direction
+	line        int
+	anchor      int
+	left        int
+	crossChecks [BoardSize]letterSet
+	placed      []Placement
+
+	moves [][]Placement
+	seen  map[string]bool
+}
+
+// square returns the column and row of position pos on the current line.
+func (g *generator) square(pos int) (int, int) {
+	if g.direction == Across {
+		return pos, g.line
+	}
+	return g.line, pos
+}
+
+// letterAt returns the letter index of the tile at pos on the current line, or zero if the square is empty
+// or out of bounds. Tiles with letters outside the lexicon alphabet are reported as index 0 too, but
+// occupied reports true for them.
+func (g *generator) letterAt(pos int) (letter byte, occupied bool) {
+	t, ok := g.board.Tile(g.square(pos))
+	if !ok {
+		return 0, false
+	}
+	return g.lexicon.index[[]rune(t.Letter)[0]], true
+}
+
+func (g *generator) generate(d Direction) {
+	g.direction = d
+	for g.line = 0; g.line < BoardSize; g.line++ {
+		g.computeCrossChecks()
+
+		prevAnchor := -1
+		for pos := 0; pos < BoardSize; pos++ {
+			if !g.isAnchor(pos) {
+				continue
+			}
+			g.anchor = pos
+
+			if _, occupied := g.letterAt(pos - 1); occupied {
+				// The part of the word before the anchor is already on the board.
+				start := pos - 1
+				for {
+					if _, occupied := g.letterAt(start - 1); !occupied {
+						break
+					}
+					start--
+				}
+				n, ok := g.lexicon.root, true
+				for i := start; i < pos && ok; i++ {
+					l, _ := g.letterAt(i)
+					n, ok = g.lexicon.child(n, l)
+				}
+				if ok {
+					g.left = 0
+					g.extendRight(n, pos, pos-start)
+				}
+			} else {
+				limit := min(pos-prevAnchor-1, RackSize-1)
+				g.leftPart(g.lexicon.root, limit, 0)
+			}
+			prevAnchor = pos
+		}
+	}
+}
+
+// isAnchor reports whether pos on the current line is an empty square that a move could extend from.
+func (g *generator) isAnchor(pos int) bool {
+	column, row := g.square(pos)
+	if g.board.Occupied(column, row) {
+		return false
+	}
+	if g.board.Empty() {
+		return column == Center && row == Center
+	}
+	return g.board.Occupied(column-1, row) || g.board.Occupied(column+1, row) ||
+		g.board.Occupied(column, row-1) || g.board.Occupied(column, row+1)
+}
+
+// computeCrossChecks computes which letters can be placed on each square of the current line without
+// forming an invalid word perpendicular to it.
+func (g *generator) computeCrossChecks() {
+	p := g.direction.Perpendicular()
+	dc, dr := p.step()
+	for pos := 0; pos < BoardSize; pos++ {
+		column, row := g.square(pos)
+		if g.board.Occupied(column, row) {
+			g.crossChecks[pos] = 0
+			continue
+		}
+		if !g.board.Occupied(column-dc, row-dr) && !g.board.Occupied(column+dc, row+dr) {
+			g.crossChecks[pos] = allLetters
+			continue
+		}
+
+		var before, after []byte
+		if w, ok := g.board.WordAt(column-dc, row-dr, p); ok {
+			before = g.encodeTiles(w.Tiles)
+		}
+		if w, ok := g.board.WordAt(column+dc, row+dr, p); ok {
+			after = g.encodeTiles(w.Tiles)
+		}
+
+		var set letterSet
+		n, ok := g.walk(g.lexicon.root, before)
+		if ok {
+			for e := g.lexicon.nodes[n]; e < g.lexicon.nodes[n+1]; e++ {
+				end, ok := g.walk(g.lexicon.edgeTargets[e], after)
+				if ok && g.lexicon.isTerminal(end) {
+					set |= 1 << g.lexicon.edgeLetters[e]
+				}
+			}
+		}
+		g.crossChecks[pos] = set
+	}
+}
+
+// encodeTiles converts tiles to letter indices. Letters outside the lexicon alphabet are encoded as 0,
+// which never matches an edge.
+func (g *generator) encodeTiles(tiles []Tile) []byte {
+	b := make([]byte, len(tiles))
+	for i, t := range tiles {
+		b[i] = g.lexicon.index[[]rune(t.Letter)[0]]
+	}
+	return b
+}
+
+func (g *generator) walk(n uint32, letters []byte) (uint32, bool) {
+	ok := true
+	for _, l := range letters {
+		n, ok = g.lexicon.child(n, l)
+		if !ok {
+			return 0, false
+		}
+	}
+	return n, true
+}
+
+// leftPart places up to limit tiles from the rack on the empty squares before the anchor, and extends
+// each resulting prefix to the right through the anchor. length is the number of tiles placed so far.
+func (g *generator) leftPart(n uint32, limit int, length int) {
+	g.left = length
+	g.extendRight(n, g.anchor, length)
+	if limit == 0 {
+		return
+	}
+
+	for e := g.lexicon.nodes[n]; e < g.lexicon.nodes[n+1]; e++ {
+		l, next := g.lexicon.edgeLetters[e], g.lexicon.edgeTargets[e]
+		g.tryTile(l, func(blank bool) {
+			// The tiles of the left part are shifted one square to the left for every tile added, so
+			// they are positioned when the move is recorded instead.
+			g.placed = append(g.placed, Placement{Letter: string(g.lexicon.letters[l]), Blank: blank})
+			g.leftPart(next, limit-1, length+1)
+			g.placed = g.placed[:len(g.placed)-1]
+		})
+	}
+}
+
+// extendRight extends the word ending in node n by placing tiles from the rack at pos and onwards.
+// length is the number of letters in the word so far.
+func (g *generator) extendRight(n uint32, pos int, length int) {
+	letter, occupied := g.letterAt(pos)
+	if occupied {
+		if next, ok := g.lexicon.child(n, letter); ok {
+			g.extendRight(next, pos+1, length+1)
+		}
+		return
+	}
+
+	if pos > g.anchor && length > 1 && g.lexicon.isTerminal(n) {
+		g.record()
+	}
+	if pos >= BoardSize {
+		return
+	}
+
+	for e := g.lexicon.nodes[n]; e < g.lexicon.nodes[n+1]; e++ {
+		l, next := g.lexicon.edgeLetters[e], g.lexicon.edgeTargets[e]
+		if !g.crossChecks[pos].has(l) {
+			continue
+		}
+		g.tryTile(l, func(blank bool) {
+			column, row := g.square(pos)
+			g.placed = append(g.placed, Place(column, row, string(g.lexicon.letters[l]), blank))
+			g.extendRight(next, pos+1, length+1)
+			g.placed = g.placed[:len(g.placed)-1]
+		})
+	}
+}
+
+// tryTile calls f once for each way letter can be played from the rack: as a regular tile and as a blank.
+// The tile is removed from the rack for the duration of the call.
+func (g *generator) tryTile(letter byte, f func(blank bool)) {
+	if g.rack[letter] > 0 {
+		g.rack[letter]--
+		f(false)
+		g.rack[letter]++
+	}
+	if g.blanks > 0 {
+		g.blanks--
+		f(true)
+		g.blanks++
+	}
+}
+
+// record records the tiles currently placed as a move.
+func (g *generator) record() {
+	move := slices.Clone(g.placed)
+	for i := 0; i < g.left; i++ {
+		move[i].Column, move[i].Row = g.square(g.anchor - g.left + i)
+	}
+	slices.SortFunc(move, func(a, b Placement) int {
+		if a.Row != b.Row {
+			return a.Row - b.Row
+		}
+		return a.Column - b.Column
+	})
+
+	// Moves of a single tile forming words in both directions are generated once for each direction.
+	var key strings.Builder
+	for _, p := range move {
+		fmt.Fprintf(&key, "%d,%d,%s,%t;", p.Column, p.Row, p.Letter, p.Blank)
+	}
+	if g.seen[key.String()] {
+		return
+	}
+	g.seen[key.String()] = true
+	g.moves = append(g.moves, move)
+}
@@ -0,0 +1,154 @@
+package wordfeud
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// moveKey returns a string identifying move regardless of the order of its placements.
+func moveKey(move []Placement) string {
+	sorted := slices.Clone(move)
+	slices.SortFunc(sorted, func(a, b Placement) int {
+		if a.Row != b.Row {
+			return a.Row - b.Row
+		}
+		return a.Column - b.Column
+	})
+	var key strings.Builder
+	for _, p := range sorted {
+		fmt.Fprintf(&key, "%s%s;", FormatCoordinate(p.Column, p.Row, Across), p.Letter)
+		if p.Blank {
+			key.WriteString("?")
+		}
+	}
+	return key.String()
+}
+
+// bruteForceMoves returns the keys of all legal moves that can be played on b using rack, by trying every
+// way of placing the tiles on every line of the board.
+func bruteForceMoves(b *BoardState, rack []string, lexicon *Lexicon) map[string]bool {
+	moves := make(map[string]bool)
+	squares := make(map[string]bool)
+	for _, d := range []Direction{Across, Down} {
+		dc, dr := d.step()
+		for line := 0; line < BoardSize; line++ {
+			for start := 0; start < BoardSize; start++ {
+				for end := start; end < BoardSize; end++ {
+					// The squares to place tiles on are the empty squares between start and end.
+					var move []Placement
+					for i := start; i <= end; i++ {
+						column, row := line*dr+i*dc, line*dc+i*dr
+						if !b.Occupied(column, row) {
+							move = append(move, Place(column, row, "A", false))
+						}
+					}
+					if len(move) == 0 || len(move) > len(rack) || squares[moveKey(move)] {
+						continue
+					}
+					squares[moveKey(move)] = true
+					if b.Validate(move) != nil {
+						continue
+					}
+					fill(move, 0, rack, make([]bool, len(rack)), lexicon, func(move []Placement) {
+						if lexicon.Check(b, move) == nil {
+							moves[moveKey(move)] = true
+						}
+					})
+				}
+			}
+		}
+	}
+	return moves
+}
+
+// fill calls f with every way of assigning the unused tiles of rack to move[i:], trying every letter of
+// the lexicon alphabet for blanks.
+func fill(move []Placement, i int, rack []string, used []bool, lexicon *Lexicon, f func([]Placement)) {
+	if i == len(move) {
+		f(move)
+		return
+	}
+	for j, t := range rack {
+		if used[j] {
+			continue
+		}
+		used[j] = true
+		if t == BlankTile {
+			for _, c := range lexicon.letters[1:] {
+				move[i].Letter, move[i].Blank = string(c), true
+				fill(move, i+1, rack, used, lexicon, f)
+			}
+		} else {
+			move[i].Letter, move[i].Blank = t, false
+			fill(move, i+1, rack, used, lexicon, f)
+		}
+		used[j] = false
+	}
+}
+
+func TestGenerateMoves(t *testing.T) {
+	lexicon := testLexicon(t)
+	rs := englishRuleset(t)
+
+	var board []Placement
+	board = append(board, word(6, 7, Across, "CAT")...)
+	board = append(board, word(8, 8, Down, "AT")...)
+	board = append(board, word(9, 10, Down, "do")...)
+
+	tests := []struct {
+		name  string
+		board []Placement
+		rack  []string
+	}{
+		{"first move", nil, []string{"C", "A", "T", "S"}},
+		{"first move with blank", nil, []string{"A", "T", BlankTile}},
+		{"no legal moves", nil, []string{"Q", "Q"}},
+		{"existing tiles", board, []string{"S", "A", "C", "T", "R"}},
+		{"existing tiles with blank", board, []string{"S", "T", BlankTile}},
+		{"existing tiles with two blanks", board, []string{BlankTile, BlankTile}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBoardState(NormalGrid, tt.board)
+			candidates, err := GenerateMoves(b, tt.rack, lexicon, rs)
+			if err != nil {
+				t.Fatalf("GenerateMoves returned error: %v", err)
+			}
+
+			got := make(map[string]bool)
+			for i, c := range candidates {
+				k := moveKey(c.Move)
+				if got[k] {
+					t.Errorf("move %s generated more than once", k)
+				}
+				got[k] = true
+				if i > 0 && c.Score.Total > candidates[i-1].Score.Total {
+					t.Errorf("move %s scoring %d is sorted after a move scoring %d", k, c.Score.Total,
+						candidates[i-1].Score.Total)
+				}
+			}
+
+			want := bruteForceMoves(b, tt.rack, lexicon)
+			for k := range want {
+				if !got[k] {
+					t.Errorf("legal move %s not generated", k)
+				}
+			}
+			for k := range got {
+				if !want[k] {
+					t.Errorf("illegal move %s generated", k)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateMovesIllegalTile(t *testing.T) {
+	_, err := GenerateMoves(&BoardState{Grid: NormalGrid}, []string{"A", "Ø"}, testLexicon(t), englishRuleset(t))
+	if err == nil {
+		t.Error("GenerateMoves returned no error for a tile outside the alphabet")
+	}
+}
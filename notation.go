@@ -0,0 +1,171 @@
+package wordfeud
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ParseCoordinate parses a coordinate in standard notation, such as 8H or H8. Columns are lettered A to O
+// and rows are numbered 1 to 15. A coordinate starting with the row, like 8H, denotes a word laid out
+// Across, and one starting with the column, like H8, a word laid out Down. Column letters are case
+// insensitive.
+func ParseCoordinate(s string) (column, row int, d Direction, err error) {
+	invalid := fmt.Errorf("invalid coordinate %q", s)
+	if len(s) < 2 {
+		return 0, 0, 0, invalid
+	}
+
+	var letter byte
+	var number string
+	switch {
+	case isColumnLetter(s[0]):
+		letter, number, d = s[0], s[1:], Down
+	case isColumnLetter(s[len(s)-1]):
+		letter, number, d = s[len(s)-1], s[:len(s)-1], Across
+	default:
+		return 0, 0, 0, invalid
+	}
+
+	n, err := strconv.Atoi(number)
+	if err != nil || strings.ContainsAny(number, "+-") {
+		return 0, 0, 0, invalid
+	}
+	column, row = int(unicode.ToUpper(rune(letter))-'A'), n-1
+	if !InBounds(column, row) {
+		return 0, 0, 0, invalid
+	}
+	return column, row, d, nil
+}
+
+func isColumnLetter(c byte) bool {
+	return c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z'
+}
+
+// FormatCoordinate returns the coordinate of column, row in standard notation for a word laid out in
+// direction d. It is the inverse of ParseCoordinate.
+func FormatCoordinate(column, row int, d Direction) string {
+	if d == Across {
+		return fmt.Sprintf("%d%c", row+1, 'A'+column)
+	}
+	return fmt.Sprintf("%c%d", 'A'+column, row+1)
+}
+
+// ParseMove parses a move in standard notation, such as 8H HE(LL)O, and returns the placements needed to
+// play it on b. The notation is a coordinate as accepted by ParseCoordinate, followed by the word as
+// described by PlaceWord.
+func (b *BoardState) ParseMove(notation string) ([]Placement, error) {
+	fields := strings.Fields(notation)
+	if len(fields) != 2 {
+		return nil, fmt.Errorf("invalid move %q: must be a coordinate followed by a word", notation)
+	}
+	column, row, d, err := ParseCoordinate(fields[0])
+	if err != nil {
+		return nil, err
+	}
+	return b.PlaceWord(column, row, d, fields[1])
+}
+
+// PlaceWord returns the placements needed to lay out word on b, starting at column, row in direction d.
+// Only the tiles that are not already on b are returned.
+//
+// Uppercase letters are played as regular tiles and lowercase letters as blanks. Letters enclosed in
+// parentheses, as well as periods, denote tiles that are already on the board. Letters without parentheses
+// that fall on a square holding the same letter are also taken to be already on the board.
+//
+// PlaceWord only checks that word is consistent with the tiles on b. Use Validate to check that the
+// resulting move is legal.
+func (b *BoardState) PlaceWord(column, row int, d Direction, word string) ([]Placement, error) {
+	dc, dr := d.step()
+	invalid := func(format string, a ...any) error {
+		return fmt.Errorf("invalid word %q: %s", word, fmt.Sprintf(format, a...))
+	}
+
+	var placements []Placement
+	parenthesized := false
+	for _, r := range word {
+		switch r {
+		case '(':
+			if parenthesized {
+				return nil, invalid("nested parentheses")
+			}
+			parenthesized = true
+			continue
+		case ')':
+			if !parenthesized {
+				return nil, invalid("unmatched parenthesis")
+			}
+			parenthesized = false
+			continue
+		}
+
+		if !InBounds(column, row) {
+			return nil, invalid("does not fit on the board")
+		}
+		square := FormatCoordinate(column, row, d)
+		t, occupied := b.Tile(column, row)
+		letter := string(unicode.ToUpper(r))
+		switch {
+		case r == '.':
+			if !occupied {
+				return nil, invalid("square %s is empty", square)
+			}
+		case occupied:
+			if t.Letter != letter {
+				return nil, invalid("square %s holds %s", square, t.Letter)
+			}
+		case parenthesized:
+			return nil, invalid("square %s is empty", square)
+		case !unicode.IsLetter(r):
+			return nil, invalid("%q is not a letter", r)
+		default:
+			placements = append(placements, Place(column, row, letter, unicode.IsLower(r)))
+		}
+		column, row = column+dc, row+dr
+	}
+	if parenthesized {
+		return nil, invalid("unmatched parenthesis")
+	}
+	if len(placements) == 0 {
+		return nil, invalid("no tiles placed")
+	}
+	return placements, nil
+}
+
+// FormatMove returns move in standard notation, as it would be played on b. The main word formed by the
+// move is written out in full, with blanks in lowercase and the tiles already on b in parentheses.
+//
+// If the move is illegal, the returned error satisfies errors.Is(err, ErrIllegalMove).
+func (b *BoardState) FormatMove(move []Placement) (string, error) {
+	main, _, err := b.formedWords(move)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(FormatCoordinate(main.Column, main.Row, main.Direction))
+	sb.WriteByte(' ')
+	dc, dr := main.Direction.step()
+	parenthesized := false
+	for i, t := range main.Tiles {
+		existing := b.Occupied(main.Column+i*dc, main.Row+i*dr)
+		if existing != parenthesized {
+			if existing {
+				sb.WriteByte('(')
+			} else {
+				sb.WriteByte(')')
+			}
+			parenthesized = existing
+		}
+		if t.Blank {
+			sb.WriteString(strings.ToLower(t.Letter))
+		} else {
+			sb.WriteString(t.Letter)
+		}
+	}
+	if parenthesized {
+		sb.WriteByte(')')
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,147 @@
+package wordfeud
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestParseCoordinate(t *testing.T) {
+	tests := []struct {
+		s           string
+		column, row int
+		d           Direction
+		wantErr     bool
+	}{
+		{s: "8H", column: 7, row: 7, d: Across},
+		{s: "H8", column: 7, row: 7, d: Down},
+		{s: "h8", column: 7, row: 7, d: Down},
+		{s: "1A", column: 0, row: 0, d: Across},
+		{s: "A1", column: 0, row: 0, d: Down},
+		{s: "15O", column: 14, row: 14, d: Across},
+		{s: "O15", column: 14, row: 14, d: Down},
+		{s: "", wantErr: true},
+		{s: "H", wantErr: true},
+		{s: "88", wantErr: true},
+		{s: "HH", wantErr: true},
+		{s: "0H", wantErr: true},
+		{s: "16H", wantErr: true},
+		{s: "P8", wantErr: true},
+		{s: "+8H", wantErr: true},
+		{s: "H-8", wantErr: true},
+		{s: "8 H", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			column, row, d, err := ParseCoordinate(tt.s)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseCoordinate(%q) returned no error", tt.s)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseCoordinate(%q) returned error: %v", tt.s, err)
+			}
+			if column != tt.column || row != tt.row || d != tt.d {
+				t.Errorf("ParseCoordinate(%q) = %d, %d, %v, want %d, %d, %v", tt.s, column, row, d, tt.column, tt.row, tt.d)
+			}
+		})
+	}
+}
+
+func TestFormatCoordinateRoundTrip(t *testing.T) {
+	for _, d := range []Direction{Across, Down} {
+		for row := 0; row < BoardSize; row++ {
+			for column := 0; column < BoardSize; column++ {
+				s := FormatCoordinate(column, row, d)
+				c, r, pd, err := ParseCoordinate(s)
+				if err != nil || c != column || r != row || pd != d {
+					t.Errorf("ParseCoordinate(%q) = %d, %d, %v, %v, want %d, %d, %v", s, c, r, pd, err, column, row, d)
+				}
+			}
+		}
+	}
+}
+
+func TestParseMove(t *testing.T) {
+	board := NewBoardState(NormalGrid, word(5, 7, Across, "HELLO"))
+	tests := []struct {
+		notation string
+		want     []Placement
+		wantErr  bool
+	}{
+		{notation: "8F HELLOS", want: word(10, 7, Across, "S")},
+		{notation: "8F (HELLO)S", want: word(10, 7, Across, "S")},
+		{notation: "8F .....s", want: word(10, 7, Across, "s")},
+		{notation: "F7 Z(H)O", want: []Placement{Place(5, 6, "Z", false), Place(5, 8, "O", false)}},
+		{notation: "F7 ZHO", want: []Placement{Place(5, 6, "Z", false), Place(5, 8, "O", false)}},
+		{notation: "8F", wantErr: true},
+		{notation: "8F HELLOS extra", wantErr: true},
+		{notation: "Z9 CAT", wantErr: true},
+		{notation: "8F JELLO", wantErr: true},
+		{notation: "8F (HELLO)", wantErr: true},
+		{notation: "8F (HELLO", wantErr: true},
+		{notation: "8F HELLO)S", wantErr: true},
+		{notation: "8F ((HELLO))S", wantErr: true},
+		{notation: "9A (CAT)", wantErr: true},
+		{notation: "9A C4T", wantErr: true},
+		{notation: "8N CATS", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.notation, func(t *testing.T) {
+			got, err := board.ParseMove(tt.notation)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseMove(%q) = %v, want error", tt.notation, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseMove(%q) returned error: %v", tt.notation, err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ParseMove(%q) = %v, want %v", tt.notation, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatMove(t *testing.T) {
+	board := NewBoardState(NormalGrid, word(5, 7, Across, "HELLO"))
+	tests := []struct {
+		name string
+		move []Placement
+		want string
+	}{
+		{"extends word", word(10, 7, Across, "s"), "8F (HELLO)s"},
+		{"through tile", []Placement{Place(5, 5, "Z", false), Place(5, 6, "A", false)}, "F6 ZA(H)"},
+		{"around tile", []Placement{Place(6, 6, "B", false), Place(6, 8, "T", false)}, "G7 B(E)T"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := board.FormatMove(tt.move)
+			if err != nil {
+				t.Fatalf("FormatMove returned error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("FormatMove = %q, want %q", got, tt.want)
+			}
+			parsed, err := board.ParseMove(got)
+			if err != nil {
+				t.Fatalf("ParseMove(%q) returned error: %v", got, err)
+			}
+			if !slices.Equal(parsed, tt.move) {
+				t.Errorf("ParseMove(%q) = %v, want %v", got, parsed, tt.move)
+			}
+		})
+	}
+
+	_, err := board.FormatMove(word(0, 0, Across, "AT"))
+	if !errors.Is(err, ErrIllegalMove) {
+		t.Errorf("got error %v formatting an illegal move, want ErrIllegalMove", err)
+	}
+}
@@ -0,0 +1,173 @@
+package wordfeud
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// limiter is a token bucket rate limiter. The bucket holds up to burst tokens and is refilled with rate
+// tokens per second. Each request takes one token, waiting for it if the bucket is empty.
+type limiter struct {
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newLimiter(rate float64, burst int) *limiter {
+	burst = max(burst, 1)
+	return &limiter{rate: rate, burst: float64(burst), tokens: float64(burst)}
+}
+
+// idle reports whether the bucket has been refilled completely at now, in which case the limiter behaves
+// exactly like a newly created one.
+func (l *limiter) idle(now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.last.IsZero() || l.tokens+now.Sub(l.last).Seconds()*l.rate >= l.burst
+}
+
+// wait blocks until a token is available or ctx is done, in which case the returned error wraps the
+// context error. If ctx has a deadline that would pass before a token becomes available, wait returns
+// an error wrapping context.DeadlineExceeded immediately.
+func (l *limiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	if !l.last.IsZero() {
+		l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		l.mu.Unlock()
+		return nil
+	}
+	d := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+	if deadline, ok := ctx.Deadline(); ok && deadline.Before(now.Add(d)) {
+		l.mu.Unlock()
+		return fmt.Errorf("waiting for rate limiter: %w", context.DeadlineExceeded)
+	}
+	// Reserve the token now, so that waiting requests are let through in order.
+	l.tokens--
+	l.mu.Unlock()
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		l.release()
+		return fmt.Errorf("waiting for rate limiter: %w", ctx.Err())
+	case <-t.C:
+		return nil
+	}
+}
+
+// release gives back a token taken by wait, for a request that was not made after all.
+func (l *limiter) release() {
+	l.mu.Lock()
+	l.tokens = min(l.burst, l.tokens+1)
+	l.mu.Unlock()
+}
+
+// WithRateLimit limits the rate of requests made by the client to requestsPerSecond on average, allowing
+// bursts of up to burst requests. Requests exceeding the limit block until they are allowed, or until
+// their context is done. It panics if requestsPerSecond is not positive.
+func WithRateLimit(requestsPerSecond float64, burst int) ClientOption {
+	checkRate("WithRateLimit", requestsPerSecond)
+	return func(c *Client) {
+		c.limiter = newLimiter(requestsPerSecond, burst)
+	}
+}
+
+// WithSessionRateLimit limits the rate of requests made by the client for each session separately, in
+// addition to any limit set using WithRateLimit. Requests that are not made on behalf of a session, such as
+// logins, are only subject to the client-wide limit. It panics if requestsPerSecond is not positive.
+func WithSessionRateLimit(requestsPerSecond float64, burst int) ClientOption {
+	checkRate("WithSessionRateLimit", requestsPerSecond)
+	return func(c *Client) {
+		c.sessionLimit = &sessionLimiters{
+			rate:     requestsPerSecond,
+			burst:    burst,
+			limiters: make(map[SessionID]*limiter),
+		}
+	}
+}
+
+// checkRate panics if rate is not a positive number of requests per second. A limiter with a rate of zero
+// would never refill its bucket, which is never what the caller intended.
+func checkRate(option string, rate float64) {
+	if !(rate > 0) {
+		panic(fmt.Sprintf("wordfeud: %s: requestsPerSecond must be positive, got %v", option, rate))
+	}
+}
+
+// minSessionSweep is the number of session limiters above which idle limiters are evicted.
+const minSessionSweep = 64
+
+type sessionLimiters struct {
+	rate  float64
+	burst int
+
+	mu       sync.Mutex
+	limiters map[SessionID]*limiter
+	// sweepAt is the number of limiters at which idle limiters are evicted next.
+	sweepAt int
+}
+
+func (s *sessionLimiters) get(session SessionID) *limiter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	l, ok := s.limiters[session]
+	if !ok {
+		if len(s.limiters) >= max(s.sweepAt, minSessionSweep) {
+			s.sweep()
+		}
+		l = newLimiter(s.rate, s.burst)
+		s.limiters[session] = l
+	}
+	return l
+}
+
+// sweep evicts the limiters of sessions that have not made requests for long enough for their bucket to
+// be refilled, such as sessions that were replaced by logging in again. Since a new limiter starts with
+// a full bucket, this does not change the rate at which requests are let through.
+func (s *sessionLimiters) sweep() {
+	now := time.Now()
+	for id, l := range s.limiters {
+		if l.idle(now) {
+			delete(s.limiters, id)
+		}
+	}
+	// Sweep again when the number of limiters has doubled, so that the cost of sweeping is spread over
+	// the sessions added in between.
+	s.sweepAt = 2 * len(s.limiters)
+}
+
+// waitForLimits blocks until a request on behalf of session is allowed by the rate limits of the client.
+func (c *Client) waitForLimits(ctx context.Context, session SessionID) error {
+	var sl *limiter
+	if c.sessionLimit != nil && session != "" {
+		sl = c.sessionLimit.get(session)
+		err := sl.wait(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	if c.limiter != nil {
+		err := c.limiter.wait(ctx)
+		if err != nil {
+			// The request will not be made, so the session token must not be used up.
+			if sl != nil {
+				sl.release()
+			}
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package wordfeud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRateLimitOptionsPanic(t *testing.T) {
+	options := map[string]func(float64, int) ClientOption{
+		"WithRateLimit":        WithRateLimit,
+		"WithSessionRateLimit": WithSessionRateLimit,
+	}
+	tests := []struct {
+		rate      float64
+		wantPanic bool
+	}{
+		{0, true},
+		{-1, true},
+		{math.NaN(), true},
+		{0.5, false},
+		{10, false},
+	}
+
+	for name, option := range options {
+		for _, tt := range tests {
+			t.Run(fmt.Sprintf("%s(%v)", name, tt.rate), func(t *testing.T) {
+				defer func() {
+					if r := recover(); (r != nil) != tt.wantPanic {
+						t.Errorf("got panic %v, want panic %v", r, tt.wantPanic)
+					}
+				}()
+				option(tt.rate, 1)
+			})
+		}
+	}
+}
+
+func TestLimiterDeadline(t *testing.T) {
+	l := newLimiter(0.001, 2)
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		err := l.wait(ctx)
+		if err != nil {
+			t.Fatalf("wait %d returned error within the burst: %v", i, err)
+		}
+	}
+
+	// The next token is 1000 seconds away, so a request with a shorter deadline fails at once.
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	start := time.Now()
+	err := l.wait(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got error %v, want context.DeadlineExceeded", err)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("wait returned after %v, want immediately", d)
+	}
+}
+
+func TestWaitForLimitsReleasesSessionToken(t *testing.T) {
+	c := NewClient(WithRateLimit(0.001, 1), WithSessionRateLimit(0.001, 1))
+	ctx := context.Background()
+
+	// Use up the token of the client-wide limiter.
+	err := c.waitForLimits(ctx, "")
+	if err != nil {
+		t.Fatalf("waitForLimits returned error: %v", err)
+	}
+
+	// The session limiter lets the request through, but the client-wide limiter does not.
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	err = c.waitForLimits(ctx, "session")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want context.DeadlineExceeded", err)
+	}
+
+	l := c.sessionLimit.get("session")
+	if l.tokens < 1 {
+		t.Errorf("session limiter has %v tokens after a request that was not made, want 1", l.tokens)
+	}
+}
+
+func TestSessionLimitersSweep(t *testing.T) {
+	s := &sessionLimiters{rate: 1, burst: 1, limiters: make(map[SessionID]*limiter)}
+	ctx := context.Background()
+
+	// The busy session has used up its token, while the other sessions have never made a request.
+	busy := s.get("busy")
+	err := busy.wait(ctx)
+	if err != nil {
+		t.Fatalf("wait returned error: %v", err)
+	}
+	for i := 1; i < minSessionSweep; i++ {
+		s.get(SessionID(fmt.Sprintf("idle %d", i)))
+	}
+	if len(s.limiters) != minSessionSweep {
+		t.Fatalf("got %d limiters, want %d", len(s.limiters), minSessionSweep)
+	}
+
+	s.get("new")
+	if len(s.limiters) != 2 {
+		t.Errorf("got %d limiters after sweeping, want the busy and the new one", len(s.limiters))
+	}
+	if s.limiters["busy"] != busy {
+		t.Error("limiter of a busy session was evicted")
+	}
+}
@@ -0,0 +1,180 @@
+package wordfeud
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// renderer holds the configuration of the text and image renderers.
+type renderer struct {
+	glyphs    glyphs
+	highlight map[[2]int]bool
+}
+
+// glyphs are the characters used to draw the squares of a board.
+type glyphs struct {
+	empty, center, dl, tl, dw, tw string
+	// frame is whether a frame is drawn around the board, using the corner and edge characters.
+	frame                                      bool
+	topLeft, topRight, bottomLeft, bottomRight string
+	horizontal, vertical                       string
+}
+
+var asciiGlyphs = glyphs{empty: ".", center: "*", dl: "'", tl: `"`, dw: "-", tw: "="}
+
+var unicodeGlyphs = glyphs{
+	empty: "·", center: "★", dl: "□", tl: "■", dw: "◇", tw: "◆",
+	frame: true, topLeft: "┌", topRight: "┐", bottomLeft: "└", bottomRight: "┘", horizontal: "─", vertical: "│",
+}
+
+type RenderOption func(*renderer)
+
+// WithUnicode draws the board using Unicode symbols and box drawing characters instead of plain ASCII.
+// It has no effect on images.
+func WithUnicode() RenderOption {
+	return func(r *renderer) {
+		r.glyphs = unicodeGlyphs
+	}
+}
+
+// WithHighlight highlights the tiles at the squares of placements. In text they are enclosed in brackets,
+// and in images they are drawn in a different color.
+func WithHighlight(placements ...Placement) RenderOption {
+	return func(r *renderer) {
+		for _, p := range placements {
+			r.highlight[[2]int{p.Column, p.Row}] = true
+		}
+	}
+}
+
+// WithLastMove highlights the tiles placed by the last move of g, if it placed any.
+func WithLastMove(g *Game) RenderOption {
+	if g.LastMove == nil || g.LastMove.MoveType != MoveTypeMove {
+		return WithHighlight()
+	}
+	return WithHighlight(g.LastMove.Move...)
+}
+
+func newRenderer(opts []RenderOption) *renderer {
+	r := &renderer{glyphs: asciiGlyphs, highlight: make(map[[2]int]bool)}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Render writes b to w as text. Columns are labelled A to O and rows 1 to 15, as in the notation accepted
+// by ParseMove. Tiles are shown by their letter, with blanks in lowercase. Empty premium squares are
+// marked, and a legend explaining the markers is written below the board.
+func (b *BoardState) Render(w io.Writer, opts ...RenderOption) error {
+	bw := bufio.NewWriter(w)
+	newRenderer(opts).board(bw, b)
+	return bw.Flush()
+}
+
+// Render writes the board of g to w as text, as described by BoardState.Render, followed by the scores of
+// the players, the racks that are known and the number of tiles left in the bag. grid is the layout of
+// the board, as returned by Client.Board. The player whose turn it is is marked with an asterisk.
+func (g *Game) Render(w io.Writer, grid Grid, opts ...RenderOption) error {
+	bw := bufio.NewWriter(w)
+	newRenderer(opts).board(bw, g.BoardState(grid))
+
+	fmt.Fprintln(bw)
+	width := 0
+	for _, p := range g.Players {
+		// Padding is measured in runes, so that names with letters such as Å line up.
+		width = max(width, utf8.RuneCountInString(p.Username))
+	}
+	for _, p := range g.Players {
+		turn := " "
+		if g.IsRunning && p.Position == g.CurrentPlayer {
+			turn = "*"
+		}
+		fmt.Fprintf(bw, "%s %-*s %4d", turn, width, p.Username, p.Score)
+		if len(p.Rack) > 0 {
+			fmt.Fprintf(bw, "  %s", formatRack(p.Rack))
+		}
+		fmt.Fprintln(bw)
+	}
+	fmt.Fprintf(bw, "Bag: %d\n", g.BagCount)
+	return bw.Flush()
+}
+
+// formatRack returns the tiles of a rack separated by spaces, with blanks shown as a question mark.
+func formatRack(rack []string) string {
+	tiles := make([]string, len(rack))
+	for i, t := range rack {
+		tiles[i] = t
+		if t == BlankTile {
+			tiles[i] = "?"
+		}
+	}
+	return strings.Join(tiles, " ")
+}
+
+func (r *renderer) board(w *bufio.Writer, b *BoardState) {
+	g := r.glyphs
+	indent := "   "
+	if g.frame {
+		indent += " "
+	}
+
+	fmt.Fprint(w, indent)
+	for column := range BoardSize {
+		fmt.Fprintf(w, " %c ", 'A'+column)
+	}
+	fmt.Fprintln(w)
+
+	if g.frame {
+		fmt.Fprintf(w, "   %s%s%s\n", g.topLeft, strings.Repeat(g.horizontal, 3*BoardSize), g.topRight)
+	}
+	for row := range BoardSize {
+		fmt.Fprintf(w, "%2d ", row+1)
+		if g.frame {
+			fmt.Fprint(w, g.vertical)
+		}
+		for column := range BoardSize {
+			left, right := " ", " "
+			if r.highlight[[2]int{column, row}] && b.Occupied(column, row) {
+				left, right = "[", "]"
+			}
+			fmt.Fprintf(w, "%s%s%s", left, r.square(b, column, row), right)
+		}
+		if g.frame {
+			fmt.Fprint(w, g.vertical)
+		}
+		fmt.Fprintln(w)
+	}
+	if g.frame {
+		fmt.Fprintf(w, "   %s%s%s\n", g.bottomLeft, strings.Repeat(g.horizontal, 3*BoardSize), g.bottomRight)
+	}
+
+	fmt.Fprintf(w, "%s TW  %s DW  %s TL  %s DL  lowercase: blank\n", g.tw, g.dw, g.tl, g.dl)
+}
+
+// square returns the glyph of the square at column, row.
+func (r *renderer) square(b *BoardState, column, row int) string {
+	if t, ok := b.Tile(column, row); ok {
+		if t.Blank {
+			return strings.ToLower(t.Letter)
+		}
+		return t.Letter
+	}
+	switch b.Grid.Square(column, row) {
+	case SquareDL:
+		return r.glyphs.dl
+	case SquareTL:
+		return r.glyphs.tl
+	case SquareDW:
+		return r.glyphs.dw
+	case SquareTW:
+		return r.glyphs.tw
+	}
+	if column == Center && row == Center {
+		return r.glyphs.center
+	}
+	return r.glyphs.empty
+}
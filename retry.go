@@ -0,0 +1,85 @@
+package wordfeud
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy controls how requests that fail because of network errors or Internal Server Errors are
+// retried. Errors reported by the API, such as ErrIllegalMove, are never retried.
+//
+// By default only GET requests, which never change any state, are retried. Requests that change state,
+// such as Move, may have been carried out by the server even though the response was lost, and are only
+// retried if RetryAll is set.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times a request is sent, including the first attempt.
+	MaxAttempts int
+	// InitialBackoff is the upper bound of the time to wait before the first retry. The bound is doubled
+	// for every subsequent retry, and the actual time to wait is chosen randomly below it.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the upper bound of the time to wait between retries. If zero, the bound is capped
+	// at one minute.
+	MaxBackoff time.Duration
+	// RetryAll enables retrying requests that change state.
+	RetryAll bool
+}
+
+// DefaultRetryPolicy makes up to 4 attempts, waiting up to 250ms, 500ms and 1s between them.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    4,
+	InitialBackoff: 250 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+}
+
+// defaultMaxBackoff caps the backoff of policies that do not set MaxBackoff.
+const defaultMaxBackoff = time.Minute
+
+// WithRetry sets the policy for retrying failed requests. By default, requests are not retried.
+func WithRetry(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retry = &policy
+	}
+}
+
+// allows reports whether a request using method may be retried after the given attempt failed.
+func (p *RetryPolicy) allows(method string, attempt int) bool {
+	if p == nil || attempt >= p.MaxAttempts {
+		return false
+	}
+	return p.RetryAll || method == http.MethodGet
+}
+
+// wait sleeps before the retry following attempt, using exponential backoff with full jitter. It returns
+// early with an error wrapping the context error if ctx is done.
+func (p *RetryPolicy) wait(ctx context.Context, attempt int) error {
+	limit := p.MaxBackoff
+	if limit <= 0 {
+		limit = defaultMaxBackoff
+	}
+	// Double the bound one step at a time instead of shifting, so that it saturates at limit instead of
+	// overflowing when there are many attempts.
+	bound := min(p.InitialBackoff, limit)
+	for i := 1; i < attempt && bound > 0 && bound < limit; i++ {
+		if bound > limit/2 {
+			bound = limit
+			break
+		}
+		bound *= 2
+	}
+	var d time.Duration
+	if bound > 0 {
+		d = rand.N(bound)
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("waiting to retry request: %w", ctx.Err())
+	case <-t.C:
+		return nil
+	}
+}
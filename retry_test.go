@@ -0,0 +1,120 @@
+package wordfeud
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// failingServer starts a server responding to every request with an Internal Server Error, and returns it
+// together with the number of requests it has received.
+func failingServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.Error(w, "<html>Internal Server Error</html>", http.StatusInternalServerError)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestRetry(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	retryAll := policy
+	retryAll.RetryAll = true
+
+	tests := []struct {
+		name   string
+		policy *RetryPolicy
+		method string
+		// requests is the number of requests the server is expected to receive.
+		requests int32
+	}{
+		{"no policy", nil, http.MethodGet, 1},
+		{"get", &policy, http.MethodGet, 3},
+		{"post", &policy, http.MethodPost, 1},
+		{"post with RetryAll", &retryAll, http.MethodPost, 3},
+		{"single attempt", &RetryPolicy{MaxAttempts: 1}, http.MethodGet, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests := failingServer(t)
+			opts := []ClientOption{WithBaseURL(server.URL)}
+			if tt.policy != nil {
+				opts = append(opts, WithRetry(*tt.policy))
+			}
+			c := NewClient(opts...)
+
+			_, err := c.request(context.Background(), tt.method, "/user/games", "", nil)
+			if err == nil {
+				t.Fatal("request returned no error for an Internal Server Error")
+			}
+			if got := requests.Load(); got != tt.requests {
+				t.Errorf("server received %d requests, want %d", got, tt.requests)
+			}
+		})
+	}
+}
+
+func TestRetryAPIError(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write([]byte(`{"status": "error", "content": {"type": "login_required"}}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(WithBaseURL(server.URL), WithRetry(RetryPolicy{MaxAttempts: 3, RetryAll: true}))
+	_, err := c.Games(context.Background(), "session")
+	if !errors.Is(err, ErrLoginRequired) {
+		t.Errorf("got error %v, want ErrLoginRequired", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("server received %d requests for an API error, want 1", got)
+	}
+}
+
+func TestRetryContextDone(t *testing.T) {
+	server, requests := failingServer(t)
+	c := NewClient(WithBaseURL(server.URL), WithRetry(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Hour}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := c.Games(ctx, "session")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got error %v, want context.DeadlineExceeded", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("server received %d requests, want 1", got)
+	}
+}
+
+func TestRetryWaitSaturates(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy RetryPolicy
+	}{
+		{"max backoff", RetryPolicy{InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}},
+		{"initial backoff above max backoff", RetryPolicy{InitialBackoff: time.Hour, MaxBackoff: time.Millisecond}},
+		{"no backoff", RetryPolicy{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Without saturation, doubling the bound this many times would overflow or wait far longer than
+			// the test timeout.
+			for _, attempt := range []int{1, 2, 64, 1000} {
+				err := tt.policy.wait(context.Background(), attempt)
+				if err != nil {
+					t.Errorf("wait(%d) returned error: %v", attempt, err)
+				}
+			}
+		})
+	}
+}
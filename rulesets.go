@@ -0,0 +1,107 @@
+package wordfeud
+
+// tile describes a single letter of a ruleset: how many of it there are in the bag at the start of a game,
+// and how many points it is worth.
+type tile struct {
+	letter string
+	count  int
+	points int
+}
+
+// BlankTile is how blank tiles are represented in a Player's Rack.
+const BlankTile = ""
+
+var englishTiles = []tile{
+	{"A", 10, 1}, {"B", 2, 4}, {"C", 2, 4}, {"D", 5, 2}, {"E", 12, 1}, {"F", 2, 4}, {"G", 3, 3},
+	{"H", 3, 4}, {"I", 9, 1}, {"J", 1, 10}, {"K", 1, 5}, {"L", 4, 1}, {"M", 2, 3}, {"N", 6, 1},
+	{"O", 7, 1}, {"P", 2, 4}, {"Q", 1, 10}, {"R", 6, 1}, {"S", 5, 1}, {"T", 7, 1}, {"U", 4, 2},
+	{"V", 2, 4}, {"W", 2, 4}, {"X", 1, 8}, {"Y", 2, 4}, {"Z", 1, 10},
+}
+
+var builtinRulesets = map[RulesetID]struct {
+	languageCode string
+	tiles        []tile
+	blanks       int
+}{
+	RuleSetAmerican: {"en-US", englishTiles, 2},
+	RuleSetNorwegian: {"nb", []tile{
+		{"A", 7, 1}, {"B", 3, 4}, {"C", 1, 10}, {"D", 5, 1}, {"E", 9, 1}, {"F", 4, 2}, {"G", 4, 2},
+		{"H", 3, 3}, {"I", 6, 1}, {"J", 2, 4}, {"K", 4, 3}, {"L", 5, 2}, {"M", 3, 2}, {"N", 6, 1},
+		{"O", 4, 3}, {"P", 2, 4}, {"R", 7, 1}, {"S", 7, 1}, {"T", 7, 1}, {"U", 3, 4}, {"V", 3, 5},
+		{"W", 1, 8}, {"Y", 1, 6}, {"Æ", 1, 6}, {"Ø", 2, 5}, {"Å", 2, 4},
+	}, 2},
+	RuleSetDutch: {"nl", []tile{
+		{"A", 7, 1}, {"B", 2, 4}, {"C", 2, 5}, {"D", 5, 2}, {"E", 18, 1}, {"F", 2, 4}, {"G", 3, 3},
+		{"H", 2, 4}, {"I", 5, 2}, {"J", 2, 4}, {"K", 3, 3}, {"L", 3, 3}, {"M", 3, 3}, {"N", 10, 1},
+		{"O", 6, 1}, {"P", 2, 4}, {"Q", 1, 10}, {"R", 5, 2}, {"S", 5, 2}, {"T", 5, 2}, {"U", 3, 2},
+		{"V", 2, 4}, {"W", 2, 5}, {"X", 1, 8}, {"Y", 1, 8}, {"Z", 2, 5},
+	}, 2},
+	RuleSetDanish: {"da", []tile{
+		{"A", 8, 1}, {"B", 4, 3}, {"C", 2, 8}, {"D", 5, 2}, {"E", 9, 1}, {"F", 3, 3}, {"G", 3, 3},
+		{"H", 2, 4}, {"I", 4, 3}, {"J", 2, 4}, {"K", 4, 3}, {"L", 5, 2}, {"M", 3, 4}, {"N", 6, 1},
+		{"O", 5, 2}, {"P", 2, 4}, {"R", 7, 1}, {"S", 6, 2}, {"T", 6, 2}, {"U", 3, 3}, {"V", 3, 4},
+		{"X", 1, 8}, {"Y", 2, 4}, {"Z", 1, 8}, {"Æ", 2, 4}, {"Ø", 2, 4}, {"Å", 2, 4},
+	}, 2},
+	RuleSetSwedish: {"sv", []tile{
+		{"A", 9, 1}, {"B", 2, 3}, {"C", 1, 8}, {"D", 5, 1}, {"E", 8, 1}, {"F", 2, 3}, {"G", 3, 2},
+		{"H", 2, 2}, {"I", 5, 1}, {"J", 1, 7}, {"K", 3, 2}, {"L", 5, 1}, {"M", 3, 2}, {"N", 6, 1},
+		{"O", 6, 2}, {"P", 2, 4}, {"R", 8, 1}, {"S", 8, 1}, {"T", 9, 1}, {"U", 3, 4}, {"V", 2, 3},
+		{"X", 1, 8}, {"Y", 1, 7}, {"Z", 1, 10}, {"Å", 2, 4}, {"Ä", 2, 3}, {"Ö", 2, 4},
+	}, 2},
+	RuleSetEnglish: {"en", englishTiles, 2},
+	RuleSetSpanish: {"es", []tile{
+		{"A", 13, 1}, {"B", 2, 3}, {"C", 4, 3}, {"D", 5, 2}, {"E", 13, 1}, {"F", 1, 4}, {"G", 2, 2},
+		{"H", 2, 4}, {"I", 6, 1}, {"J", 1, 8}, {"L", 4, 1}, {"M", 2, 3}, {"N", 6, 1}, {"Ñ", 1, 8},
+		{"O", 10, 1}, {"P", 2, 3}, {"Q", 1, 5}, {"R", 6, 1}, {"S", 7, 1}, {"T", 5, 1}, {"U", 5, 1},
+		{"V", 1, 4}, {"X", 1, 8}, {"Y", 1, 4}, {"Z", 1, 10},
+	}, 2},
+	RuleSetFrench: {"fr", []tile{
+		{"A", 10, 1}, {"B", 2, 3}, {"C", 2, 3}, {"D", 3, 2}, {"E", 16, 1}, {"F", 2, 4}, {"G", 2, 2},
+		{"H", 2, 4}, {"I", 8, 1}, {"J", 1, 8}, {"K", 1, 10}, {"L", 5, 1}, {"M", 3, 2}, {"N", 6, 1},
+		{"O", 6, 1}, {"P", 2, 3}, {"Q", 1, 8}, {"R", 6, 1}, {"S", 6, 1}, {"T", 6, 1}, {"U", 6, 1},
+		{"V", 2, 4}, {"W", 1, 10}, {"X", 1, 10}, {"Y", 1, 10}, {"Z", 1, 10},
+	}, 2},
+}
+
+// BuiltinRuleset returns the tile distribution and letter values of a ruleset, as shipped with this package.
+// The second return value is false if the ruleset is unknown.
+//
+// The returned Ruleset is a copy and may be modified freely.
+func BuiltinRuleset(id RulesetID) (*Ruleset, bool) {
+	b, ok := builtinRulesets[id]
+	if !ok {
+		return nil, false
+	}
+
+	r := &Ruleset{
+		Ruleset:      id,
+		LanguageCode: b.languageCode,
+		Alphabet:     make([]string, 0, len(b.tiles)),
+		TilePoints:   make(map[string]int, len(b.tiles)),
+		TileCounts:   make(map[string]int, len(b.tiles)),
+		Blanks:       b.blanks,
+	}
+	for _, t := range b.tiles {
+		r.Alphabet = append(r.Alphabet, t.letter)
+		r.TilePoints[t.letter] = t.points
+		r.TileCounts[t.letter] = t.count
+	}
+	return r, true
+}
+
+// Points returns how many points a letter is worth. Blank tiles are always worth zero points.
+func (r *Ruleset) Points(letter string, blank bool) int {
+	if blank {
+		return 0
+	}
+	return r.TilePoints[letter]
+}
+
+// TileCount returns the total number of tiles in the bag at the start of a game, including blanks.
+func (r *Ruleset) TileCount() int {
+	n := r.Blanks
+	for _, c := range r.TileCounts {
+		n += c
+	}
+	return n
+}
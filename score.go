@@ -0,0 +1,122 @@
+package wordfeud
+
+// RackSize is the maximum number of tiles on a player's rack.
+const RackSize = 7
+
+// AllTilesBonus is the number of bonus points awarded for using all tiles on a full rack in a single move.
+const AllTilesBonus = 40
+
+// WordScore is a word formed by a move, together with the points it is worth.
+type WordScore struct {
+	Word   Word
+	Points int
+}
+
+// Score is the result of scoring a move.
+type Score struct {
+	// MainWord is the word formed in the direction the tiles were placed.
+	MainWord WordScore
+	// CrossWords are the words formed perpendicular to the direction the tiles were placed.
+	CrossWords []WordScore
+	// Bonus is AllTilesBonus if a full rack was used, otherwise zero.
+	Bonus int
+	// Total is the total number of points the move is worth.
+	Total int
+}
+
+// Words returns all words formed by the move, starting with the main word.
+func (s *Score) Words() []WordScore {
+	return append([]WordScore{s.MainWord}, s.CrossWords...)
+}
+
+// Score calculates the points move is worth when played on b, using the letter values of ruleset and the
+// premium squares of b.Grid. Premium squares only apply to the tiles placed by move, and blank tiles are
+// always worth zero points.
+//
+// If the move is illegal, the returned error satisfies errors.Is(err, ErrIllegalMove).
+func (b *BoardState) Score(move []Placement, ruleset *Ruleset) (*Score, error) {
+	main, cross, err := b.formedWords(move)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Score{MainWord: b.scoreWord(main, ruleset)}
+	for _, w := range cross {
+		s.CrossWords = append(s.CrossWords, b.scoreWord(w, ruleset))
+	}
+
+	if len(move) == RackSize {
+		s.Bonus = AllTilesBonus
+	}
+
+	s.Total = s.MainWord.Points + s.Bonus
+	for _, w := range s.CrossWords {
+		s.Total += w.Points
+	}
+	return s, nil
+}
+
+// MoveWords returns all words of at least two letters formed by playing move on b, starting with the word
+// formed in the direction the tiles were placed.
+//
+// If the move is illegal, the returned error satisfies errors.Is(err, ErrIllegalMove).
+func (b *BoardState) MoveWords(move []Placement) ([]Word, error) {
+	main, cross, err := b.formedWords(move)
+	if err != nil {
+		return nil, err
+	}
+	return append([]Word{main}, cross...), nil
+}
+
+// formedWords validates move and returns the main word and cross words it forms when played on b.
+func (b *BoardState) formedWords(move []Placement) (Word, []Word, error) {
+	d, err := b.direction(move)
+	if err != nil {
+		return Word{}, nil, err
+	}
+
+	after := b.Clone()
+	after.Place(move...)
+
+	var main Word
+	var cross []Word
+	if w, ok := after.WordAt(move[0].Column, move[0].Row, d); ok && len(w.Tiles) > 1 {
+		main = w
+	}
+	for _, p := range move {
+		if w, ok := after.WordAt(p.Column, p.Row, d.Perpendicular()); ok && len(w.Tiles) > 1 {
+			cross = append(cross, w)
+		}
+	}
+
+	// A single tile only forming a word perpendicular to its direction has no main word of its own.
+	if len(main.Tiles) == 0 && len(cross) > 0 {
+		main, cross = cross[0], cross[1:]
+	}
+	return main, cross, nil
+}
+
+// scoreWord calculates the points of w, which is a word on the board after a move has been played on b.
+// Tiles of w that are not already on b are considered newly placed and have premium squares applied.
+func (b *BoardState) scoreWord(w Word, ruleset *Ruleset) WordScore {
+	dc, dr := w.Direction.step()
+	sum, multiplier := 0, 1
+	for i, t := range w.Tiles {
+		column, row := w.Column+i*dc, w.Row+i*dr
+		points := ruleset.Points(t.Letter, t.Blank)
+		if !b.Occupied(column, row) {
+			switch b.Grid.Square(column, row) {
+			case SquareDL:
+				points *= 2
+			case SquareTL:
+				points *= 3
+			case SquareDW:
+				multiplier *= 2
+			case SquareTW:
+				multiplier *= 3
+			}
+		}
+		sum += points
+	}
+	return WordScore{Word: w, Points: sum * multiplier}
+}
@@ -0,0 +1,145 @@
+package wordfeud
+
+import (
+	"errors"
+	"testing"
+	"unicode"
+)
+
+// word returns the placements of word laid out in direction d starting at column, row. Lower case letters
+// are placed as blanks.
+func word(column, row int, d Direction, word string) []Placement {
+	dc, dr := d.step()
+	var placements []Placement
+	for i, c := range word {
+		placements = append(placements, Place(column+i*dc, row+i*dr, string(unicode.ToUpper(c)), unicode.IsLower(c)))
+	}
+	return placements
+}
+
+func englishRuleset(t *testing.T) *Ruleset {
+	t.Helper()
+	rs, ok := BuiltinRuleset(RuleSetEnglish)
+	if !ok {
+		t.Fatal("English ruleset not found")
+	}
+	return rs
+}
+
+func TestScore(t *testing.T) {
+	rs := englishRuleset(t)
+	tests := []struct {
+		name  string
+		board []Placement
+		move  []Placement
+		// words are the expected words formed by the move with their points, starting with the main word.
+		words []WordScore
+		bonus int
+		total int
+	}{
+		{
+			name:  "first move on normal squares",
+			move:  word(5, 7, Across, "HELLO"),
+			words: []WordScore{{Word: Word{Column: 5, Row: 7}, Points: 8}},
+			total: 8,
+		},
+		{
+			name:  "double word",
+			move:  word(3, 7, Across, "HELLO"),
+			words: []WordScore{{Word: Word{Column: 3, Row: 7}, Points: 16}},
+			total: 16,
+		},
+		{
+			name:  "blank on double word",
+			move:  word(3, 7, Across, "hELLO"),
+			words: []WordScore{{Word: Word{Column: 3, Row: 7}, Points: 8}},
+			total: 8,
+		},
+		{
+			name:  "all tiles bonus",
+			move:  word(1, 7, Across, "EXAMPLE"),
+			words: []WordScore{{Word: Word{Column: 1, Row: 7}, Points: 38}},
+			bonus: AllTilesBonus,
+			total: 78,
+		},
+		{
+			name:  "triple letter through existing tile",
+			board: word(5, 7, Across, "HELLO"),
+			move:  word(5, 5, Down, "ZA"),
+			// Z on a triple letter square, and H already on the board.
+			words: []WordScore{{Word: Word{Column: 5, Row: 5, Direction: Down}, Points: 35}},
+			total: 35,
+		},
+		{
+			name:  "blank on triple letter",
+			board: word(5, 7, Across, "HELLO"),
+			move:  word(5, 5, Down, "zA"),
+			words: []WordScore{{Word: Word{Column: 5, Row: 5, Direction: Down}, Points: 5}},
+			total: 5,
+		},
+		{
+			name:  "premium under existing tile is not counted again",
+			board: word(3, 7, Across, "HELLO"),
+			move:  word(8, 7, Across, "S"),
+			words: []WordScore{{Word: Word{Column: 3, Row: 7}, Points: 9}},
+			total: 9,
+		},
+		{
+			name:  "cross word",
+			board: word(5, 7, Across, "HELLO"),
+			move:  word(9, 8, Across, "AX"),
+			// X on a double letter square, and O above A forming a cross word.
+			words: []WordScore{
+				{Word: Word{Column: 9, Row: 8}, Points: 17},
+				{Word: Word{Column: 9, Row: 7, Direction: Down}, Points: 2},
+			},
+			total: 19,
+		},
+		{
+			name:  "single tile forming a word down only",
+			board: word(5, 7, Across, "HELLO"),
+			move:  word(5, 8, Across, "A"),
+			words: []WordScore{{Word: Word{Column: 5, Row: 7, Direction: Down}, Points: 5}},
+			total: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBoardState(NormalGrid, tt.board)
+			s, err := b.Score(tt.move, rs)
+			if err != nil {
+				t.Fatalf("Score returned error: %v", err)
+			}
+
+			words := s.Words()
+			if len(words) != len(tt.words) {
+				t.Fatalf("got %d words, want %d", len(words), len(tt.words))
+			}
+			for i, w := range words {
+				want := tt.words[i]
+				if w.Word.Column != want.Word.Column || w.Word.Row != want.Word.Row || w.Word.Direction != want.Word.Direction {
+					t.Errorf("word %d %q starts at %d,%d %v, want %d,%d %v", i, w.Word, w.Word.Column, w.Word.Row,
+						w.Word.Direction, want.Word.Column, want.Word.Row, want.Word.Direction)
+				}
+				if w.Points != want.Points {
+					t.Errorf("word %d %q is worth %d points, want %d", i, w.Word, w.Points, want.Points)
+				}
+			}
+			if s.Bonus != tt.bonus {
+				t.Errorf("got bonus %d, want %d", s.Bonus, tt.bonus)
+			}
+			if s.Total != tt.total {
+				t.Errorf("got total %d, want %d", s.Total, tt.total)
+			}
+		})
+	}
+}
+
+func TestScoreIllegalMove(t *testing.T) {
+	b := NewBoardState(NormalGrid, word(5, 7, Across, "HELLO"))
+	_, err := b.Score(word(0, 0, Across, "AT"), englishRuleset(t))
+	if !errors.Is(err, ErrIllegalMove) {
+		t.Errorf("got error %v, want ErrIllegalMove", err)
+	}
+}
@@ -0,0 +1,310 @@
+package wordfeud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Credentials are used by Session to log in. If Email is set the user is logged in by email, otherwise
+// by ID. If Password is empty, PasswordHash is sent instead, as returned by HashPassword.
+type Credentials struct {
+	Email        string
+	ID           UserID
+	Password     string
+	PasswordHash string
+}
+
+// account returns the key identifying the account in a SessionStore.
+func (c Credentials) account() string {
+	if c.Email != "" {
+		return c.Email
+	}
+	return fmt.Sprintf("id:%d", c.ID)
+}
+
+func (c Credentials) passwordHash() string {
+	if c.Password != "" {
+		return HashPassword(c.Password)
+	}
+	return c.PasswordHash
+}
+
+// Session is an authenticated session that logs in as needed. It logs in when first used, and whenever
+// the API responds with ErrLoginRequired it logs in again and retries the call once. If a SessionStore
+// is configured, session ids are reused across processes and only replaced when rejected by the API.
+//
+// Failing to save a new session id to the store does not stop the call that needed it: the call is made
+// anyway, and if it succeeds its result is returned together with an error matching ErrSessionNotSaved.
+//
+// Session has the same methods as Client, without the session parameter. It is safe for concurrent use
+// by multiple goroutines.
+type Session struct {
+	client *Client
+	store  SessionStore
+
+	mu          sync.Mutex
+	credentials Credentials
+	id          SessionID
+}
+
+type SessionOption func(*Session)
+
+// ErrSessionNotSaved is returned by Session methods when logging in succeeded but the new session id
+// could not be saved to the SessionStore. The call itself was made, and its result is returned along
+// with the error.
+var ErrSessionNotSaved = errors.New("session not saved")
+
+// WithSessionStore makes the session load its id from store before logging in, and save its id to
+// store after logging in.
+func WithSessionStore(store SessionStore) SessionOption {
+	return func(s *Session) {
+		s.store = store
+	}
+}
+
+// NewSession returns a Session that performs requests using client and logs in using credentials.
+func NewSession(client *Client, credentials Credentials, opts ...SessionOption) *Session {
+	s := &Session{client: client, credentials: credentials}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// ID returns the current session id. If there is none, it is loaded from the session store or, failing
+// that, obtained by logging in. If the new id could not be saved to the store, it is returned together
+// with an error matching ErrSessionNotSaved.
+func (s *Session) ID(ctx context.Context) (SessionID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.id != "" {
+		return s.id, nil
+	}
+	if s.store != nil {
+		id, err := s.store.Load(ctx, s.credentials.account())
+		if err != nil {
+			return "", fmt.Errorf("loading session: %v", err)
+		}
+		if id != "" {
+			s.id = id
+			return id, nil
+		}
+	}
+	return s.login(ctx)
+}
+
+// relogin logs in again, unless another goroutine has already done so since expired was rejected.
+func (s *Session) relogin(ctx context.Context, expired SessionID) (SessionID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.id != "" && s.id != expired {
+		return s.id, nil
+	}
+	return s.login(ctx)
+}
+
+// login logs in and stores the new session id. If storing fails, the id is returned together with an error
+// matching ErrSessionNotSaved. s.mu must be held.
+func (s *Session) login(ctx context.Context) (SessionID, error) {
+	var id SessionID
+	var err error
+	if s.credentials.Email != "" {
+		id, err = s.client.loginWithEmail(ctx, s.credentials.Email, s.credentials.passwordHash())
+	} else {
+		id, err = s.client.loginWithID(ctx, s.credentials.ID, s.credentials.passwordHash())
+	}
+	if err != nil {
+		return "", err
+	}
+	s.id = id
+
+	if s.store != nil {
+		err = s.store.Save(ctx, s.credentials.account(), id)
+		if err != nil {
+			return id, fmt.Errorf("%w: %v", ErrSessionNotSaved, err)
+		}
+	}
+	return id, nil
+}
+
+// Do calls f with the current session id, logging in first if needed. If f returns an error matching
+// ErrLoginRequired, Do logs in again and calls f once more with the new session id.
+func (s *Session) Do(ctx context.Context, f func(session SessionID) error) error {
+	_, err := withSession(ctx, s, func(session SessionID) (struct{}, error) {
+		return struct{}{}, f(session)
+	})
+	return err
+}
+
+func withSession[T any](ctx context.Context, s *Session, f func(session SessionID) (T, error)) (T, error) {
+	// A session id that could not be saved is still used, and the save error is only reported if the
+	// call succeeds.
+	var saveErr error
+	id, err := s.ID(ctx)
+	if errors.Is(err, ErrSessionNotSaved) {
+		saveErr, err = err, nil
+	}
+	if err != nil {
+		return *new(T), err
+	}
+	res, err := f(id)
+
+	if errors.Is(err, ErrLoginRequired) {
+		id, err = s.relogin(ctx, id)
+		if errors.Is(err, ErrSessionNotSaved) {
+			saveErr, err = err, nil
+		}
+		if err != nil {
+			return *new(T), err
+		}
+		res, err = f(id)
+	}
+	if err == nil && saveErr != nil {
+		return res, saveErr
+	}
+	return res, err
+}
+
+// ChangePassword changes the password of the user. The new password is used for subsequent logins.
+func (s *Session) ChangePassword(ctx context.Context, newPassword string) error {
+	err := s.Do(ctx, func(session SessionID) error {
+		return s.client.ChangePassword(ctx, session, newPassword)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.credentials.Password = newPassword
+	return nil
+}
+
+// UpdateAvatar updates the avatar of the user and returns the time it was updated, as reported by the server.
+func (s *Session) UpdateAvatar(ctx context.Context, image io.ReadSeeker) (Timestamp, error) {
+	return withSession(ctx, s, func(session SessionID) (Timestamp, error) {
+		// The image has to be read again if the call is retried.
+		_, err := image.Seek(0, io.SeekStart)
+		if err != nil {
+			return Timestamp{}, err
+		}
+		return s.client.UpdateAvatar(ctx, session, image)
+	})
+}
+
+// Relationships returns all the friends of the user.
+func (s *Session) Relationships(ctx context.Context) ([]Relationship, error) {
+	return withSession(ctx, s, func(session SessionID) ([]Relationship, error) {
+		return s.client.Relationships(ctx, session)
+	})
+}
+
+// CreateRelationship adds a user to the friends list.
+func (s *Session) CreateRelationship(ctx context.Context, user UserID) (*Relationship, error) {
+	return withSession(ctx, s, func(session SessionID) (*Relationship, error) {
+		return s.client.CreateRelationship(ctx, session, user)
+	})
+}
+
+// DeleteRelationship removes a user from the friends list.
+func (s *Session) DeleteRelationship(ctx context.Context, user UserID) error {
+	return s.Do(ctx, func(session SessionID) error {
+		return s.client.DeleteRelationship(ctx, session, user)
+	})
+}
+
+// Games returns all ongoing games the user is participating in, as well as recently finished ones.
+func (s *Session) Games(ctx context.Context) ([]Game, error) {
+	return withSession(ctx, s, func(session SessionID) ([]Game, error) {
+		return s.client.Games(ctx, session)
+	})
+}
+
+// Status returns a summary of the games and invitations of the user.
+func (s *Session) Status(ctx context.Context) (*Status, error) {
+	return withSession(ctx, s, func(session SessionID) (*Status, error) {
+		return s.client.Status(ctx, session)
+	})
+}
+
+// Game returns a single game.
+func (s *Session) Game(ctx context.Context, game GameID) (*Game, error) {
+	return withSession(ctx, s, func(session SessionID) (*Game, error) {
+		return s.client.Game(ctx, session, game)
+	})
+}
+
+// Invite invites a player to a new game by username.
+func (s *Session) Invite(ctx context.Context, username string, ruleset RulesetID, board BoardID) (*Invitation, error) {
+	return withSession(ctx, s, func(session SessionID) (*Invitation, error) {
+		return s.client.Invite(ctx, session, username, ruleset, board)
+	})
+}
+
+// InviteRandomOpponent invites a random opponent to a new game.
+func (s *Session) InviteRandomOpponent(ctx context.Context, ruleset RulesetID, board BoardID) (*Invitation, error) {
+	return withSession(ctx, s, func(session SessionID) (*Invitation, error) {
+		return s.client.InviteRandomOpponent(ctx, session, ruleset, board)
+	})
+}
+
+// AcceptInvitation accepts a game invitation and returns the id of the resulting game.
+func (s *Session) AcceptInvitation(ctx context.Context, invitation InvitationID) (GameID, error) {
+	return withSession(ctx, s, func(session SessionID) (GameID, error) {
+		return s.client.AcceptInvitation(ctx, session, invitation)
+	})
+}
+
+// RejectInvitation rejects a game invitation.
+func (s *Session) RejectInvitation(ctx context.Context, invitation InvitationID) error {
+	return s.Do(ctx, func(session SessionID) error {
+		return s.client.RejectInvitation(ctx, session, invitation)
+	})
+}
+
+// Move performs a move.
+func (s *Session) Move(ctx context.Context, game GameID, move []Placement) (*MoveResult, error) {
+	return withSession(ctx, s, func(session SessionID) (*MoveResult, error) {
+		return s.client.Move(ctx, session, game, move)
+	})
+}
+
+// Pass passes the turn to the opponent.
+func (s *Session) Pass(ctx context.Context, game GameID) (*MoveResult, error) {
+	return withSession(ctx, s, func(session SessionID) (*MoveResult, error) {
+		return s.client.Pass(ctx, session, game)
+	})
+}
+
+// Swap exchanges tiles from the rack with new ones from the bag, passing the turn to the opponent.
+func (s *Session) Swap(ctx context.Context, game GameID, tiles []string) (*MoveResult, error) {
+	return withSession(ctx, s, func(session SessionID) (*MoveResult, error) {
+		return s.client.Swap(ctx, session, game, tiles)
+	})
+}
+
+// Resign resigns from a game.
+func (s *Session) Resign(ctx context.Context, game GameID) (*MoveResult, error) {
+	return withSession(ctx, s, func(session SessionID) (*MoveResult, error) {
+		return s.client.Resign(ctx, session, game)
+	})
+}
+
+// ChatMessages returns all the chat messages sent in a game.
+func (s *Session) ChatMessages(ctx context.Context, game GameID) ([]Message, error) {
+	return withSession(ctx, s, func(session SessionID) ([]Message, error) {
+		return s.client.ChatMessages(ctx, session, game)
+	})
+}
+
+// SendChatMessage sends a chat message and returns the time it was sent, as reported by the server.
+func (s *Session) SendChatMessage(ctx context.Context, game GameID, message string) (Timestamp, error) {
+	return withSession(ctx, s, func(session SessionID) (Timestamp, error) {
+		return s.client.SendChatMessage(ctx, session, game, message)
+	})
+}
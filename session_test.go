@@ -0,0 +1,167 @@
+package wordfeud_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/kayex/wordfeud"
+	"github.com/kayex/wordfeud/wordfeudtest"
+)
+
+// memoryStore is a SessionStore keeping session ids in memory. If err is set, Save fails with it.
+type memoryStore struct {
+	sessions map[string]wordfeud.SessionID
+	saves    int
+	err      error
+}
+
+func (m *memoryStore) Load(_ context.Context, account string) (wordfeud.SessionID, error) {
+	return m.sessions[account], nil
+}
+
+func (m *memoryStore) Save(_ context.Context, account string, session wordfeud.SessionID) error {
+	m.saves++
+	if m.err != nil {
+		return m.err
+	}
+	if m.sessions == nil {
+		m.sessions = make(map[string]wordfeud.SessionID)
+	}
+	m.sessions[account] = session
+	return nil
+}
+
+func sessionServer(t *testing.T) (*wordfeudtest.Server, wordfeud.UserID) {
+	t.Helper()
+	server := wordfeudtest.NewServer()
+	t.Cleanup(server.Close)
+	return server, server.AddUser("alice", "alice@example.com", "alice password")
+}
+
+func TestSessionRelogin(t *testing.T) {
+	server, id := sessionServer(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		credentials wordfeud.Credentials
+	}{
+		{"email", wordfeud.Credentials{Email: "alice@example.com", Password: "alice password"}},
+		{"id", wordfeud.Credentials{ID: id, Password: "alice password"}},
+		{"password hash", wordfeud.Credentials{Email: "alice@example.com", PasswordHash: wordfeud.HashPassword("alice password")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &memoryStore{}
+			s := wordfeud.NewSession(server.Client(), tt.credentials, wordfeud.WithSessionStore(store))
+			_, err := s.Games(ctx)
+			if err != nil {
+				t.Fatalf("Games returned error: %v", err)
+			}
+			first, err := s.ID(ctx)
+			if err != nil {
+				t.Fatalf("ID returned error: %v", err)
+			}
+
+			server.ExpireSessions()
+			_, err = s.Games(ctx)
+			if err != nil {
+				t.Fatalf("Games returned error after the session expired: %v", err)
+			}
+			second, err := s.ID(ctx)
+			if err != nil {
+				t.Fatalf("ID returned error: %v", err)
+			}
+			if second == first {
+				t.Error("session id was not replaced after the session expired")
+			}
+			if store.saves != 2 {
+				t.Errorf("session saved %d times, want 2", store.saves)
+			}
+		})
+	}
+}
+
+func TestSessionWrongPassword(t *testing.T) {
+	server, _ := sessionServer(t)
+	s := wordfeud.NewSession(server.Client(), wordfeud.Credentials{Email: "alice@example.com", Password: "wrong"})
+	_, err := s.Games(context.Background())
+	if !errors.Is(err, wordfeud.ErrWrongPassword) {
+		t.Errorf("got error %v, want ErrWrongPassword", err)
+	}
+}
+
+func TestSessionStore(t *testing.T) {
+	server, _ := sessionServer(t)
+	client := server.Client()
+	credentials := wordfeud.Credentials{Email: "alice@example.com", Password: "alice password"}
+	ctx := context.Background()
+
+	store := wordfeud.NewFileSessionStore(filepath.Join(t.TempDir(), "sessions.json"))
+	first, err := wordfeud.NewSession(client, credentials, wordfeud.WithSessionStore(store)).ID(ctx)
+	if err != nil {
+		t.Fatalf("ID returned error: %v", err)
+	}
+
+	// A second session with the same store reuses the saved id instead of logging in.
+	s := wordfeud.NewSession(client, credentials, wordfeud.WithSessionStore(store))
+	second, err := s.ID(ctx)
+	if err != nil {
+		t.Fatalf("ID returned error: %v", err)
+	}
+	if second != first {
+		t.Errorf("got session %q, want the stored session %q", second, first)
+	}
+
+	// A stored id rejected by the server is replaced.
+	server.ExpireSessions()
+	_, err = s.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status returned error after the stored session expired: %v", err)
+	}
+	stored, err := store.Load(ctx, "alice@example.com")
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if stored == first {
+		t.Error("expired session id was not replaced in the store")
+	}
+}
+
+func TestSessionNotSaved(t *testing.T) {
+	server, _ := sessionServer(t)
+	ctx := context.Background()
+	saveErr := errors.New("disk full")
+
+	store := &memoryStore{err: saveErr}
+	s := wordfeud.NewSession(server.Client(), wordfeud.Credentials{Email: "alice@example.com", Password: "alice password"},
+		wordfeud.WithSessionStore(store))
+
+	// The call is made with the unsaved session, and its result is returned along with the save error.
+	games, err := s.Games(ctx)
+	if !errors.Is(err, wordfeud.ErrSessionNotSaved) {
+		t.Errorf("got error %v, want ErrSessionNotSaved", err)
+	}
+	if games == nil {
+		t.Error("Games returned no result along with ErrSessionNotSaved")
+	}
+
+	// The session is kept in memory, so later calls neither log in again nor fail.
+	_, err = s.Games(ctx)
+	if err != nil {
+		t.Errorf("Games returned error for the session kept in memory: %v", err)
+	}
+	if store.saves != 1 {
+		t.Errorf("session saved %d times, want 1", store.saves)
+	}
+
+	// A failing call takes precedence over the save error.
+	server.ExpireSessions()
+	_, err = s.Game(ctx, 12345)
+	if err == nil || errors.Is(err, wordfeud.ErrSessionNotSaved) {
+		t.Errorf("got error %v for a missing game, want the error of the call", err)
+	}
+}
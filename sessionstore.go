@@ -0,0 +1,103 @@
+package wordfeud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// SessionStore persists session ids across process restarts. Accounts are identified by the email address
+// used to log in, or by "id:" followed by the user id.
+type SessionStore interface {
+	// Load returns the stored session id of account, or the empty string if there is none.
+	Load(ctx context.Context, account string) (SessionID, error)
+	// Save stores the session id of account, replacing any previously stored one.
+	Save(ctx context.Context, account string, session SessionID) error
+}
+
+// FileSessionStore is a SessionStore that keeps session ids in a JSON file. It is safe for concurrent
+// use by multiple goroutines, but not by multiple processes.
+type FileSessionStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileSessionStore returns a FileSessionStore that keeps session ids in the file at path. The file is
+// created when the first session is saved.
+func NewFileSessionStore(path string) *FileSessionStore {
+	return &FileSessionStore{path: path}
+}
+
+func (f *FileSessionStore) Load(_ context.Context, account string) (SessionID, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	sessions, err := f.read()
+	if err != nil {
+		return "", err
+	}
+	return sessions[account], nil
+}
+
+func (f *FileSessionStore) Save(_ context.Context, account string, session SessionID) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	sessions, err := f.read()
+	if err != nil {
+		return err
+	}
+	sessions[account] = session
+
+	b, err := json.MarshalIndent(sessions, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshalling sessions: %v", err)
+	}
+
+	err = writeFile(f.path, b)
+	if err != nil {
+		return fmt.Errorf("writing session file: %v", err)
+	}
+	return nil
+}
+
+// writeFile writes b to the file at path through a temporary file, so that the file is never left
+// half-written.
+func writeFile(path string, b []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(b)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (f *FileSessionStore) read() (map[string]SessionID, error) {
+	sessions := make(map[string]SessionID)
+	b, err := os.ReadFile(f.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return sessions, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading session file: %v", err)
+	}
+	err = json.Unmarshal(b, &sessions)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshalling session file: %v", err)
+	}
+	return sessions, nil
+}
@@ -0,0 +1,109 @@
+package wordfeud
+
+import "fmt"
+
+// Validate checks that move is legal to play on b, without checking the words it forms against a
+// dictionary. The move must consist of at least one tile, all tiles must be in bounds and on empty squares,
+// laid out in a single line without gaps (not counting tiles already on the board), and connected to the
+// tiles already on the board. The first move of a game must cover the center square and form a word
+// of at least two letters.
+//
+// The returned error, if any, describes why the move is illegal and satisfies errors.Is(err, ErrIllegalMove).
+func (b *BoardState) Validate(move []Placement) error {
+	_, err := b.direction(move)
+	return err
+}
+
+func illegalMove(format string, a ...any) error {
+	return fmt.Errorf("%w: %s", ErrIllegalMove, fmt.Sprintf(format, a...))
+}
+
+// direction validates move and returns the direction in which it is laid out. Moves consisting of a single
+// tile are considered to be Across, unless they only form a word in the Down direction.
+func (b *BoardState) direction(move []Placement) (Direction, error) {
+	if len(move) == 0 {
+		return 0, illegalMove("no tiles placed")
+	}
+	if !uniqueSquares(move) {
+		return 0, illegalMove("multiple tiles placed on the same square")
+	}
+	for _, p := range move {
+		if !InBounds(p.Column, p.Row) {
+			return 0, illegalMove("square %d,%d is out of bounds", p.Column, p.Row)
+		}
+		if p.Letter == "" {
+			return 0, illegalMove("no letter given for square %d,%d", p.Column, p.Row)
+		}
+		if b.Occupied(p.Column, p.Row) {
+			return 0, illegalMove("square %d,%d is already occupied", p.Column, p.Row)
+		}
+	}
+
+	d := Across
+	first := move[0]
+	sameRow, sameColumn := true, true
+	for _, p := range move[1:] {
+		sameRow = sameRow && p.Row == first.Row
+		sameColumn = sameColumn && p.Column == first.Column
+	}
+	switch {
+	case len(move) == 1:
+		if !b.Occupied(first.Column-1, first.Row) && !b.Occupied(first.Column+1, first.Row) {
+			d = Down
+		}
+	case sameRow:
+		d = Across
+	case sameColumn:
+		d = Down
+	default:
+		return 0, illegalMove("tiles are not placed in a single line")
+	}
+
+	after := b.Clone()
+	after.Place(move...)
+	lo, hi := extent(move, d)
+	for i := lo; i <= hi; i++ {
+		column, row := first.Column, first.Row
+		if d == Across {
+			column = i
+		} else {
+			row = i
+		}
+		if !after.Occupied(column, row) {
+			return 0, illegalMove("tiles are not contiguous")
+		}
+	}
+
+	if b.Empty() {
+		if !after.Occupied(Center, Center) {
+			return 0, illegalMove("first move must cover the center square")
+		}
+		if len(move) < 2 {
+			return 0, illegalMove("first move must form a word of at least two letters")
+		}
+		return d, nil
+	}
+
+	for _, p := range move {
+		for _, n := range [][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}} {
+			if b.Occupied(p.Column+n[0], p.Row+n[1]) {
+				return d, nil
+			}
+		}
+	}
+	return 0, illegalMove("tiles are not connected to any tile on the board")
+}
+
+// extent returns the lowest and highest column (for Across) or row (for Down) covered by move.
+func extent(move []Placement, d Direction) (int, int) {
+	lo, hi := BoardSize, -1
+	for _, p := range move {
+		i := p.Column
+		if d == Down {
+			i = p.Row
+		}
+		lo = min(lo, i)
+		hi = max(hi, i)
+	}
+	return lo, hi
+}
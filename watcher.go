@@ -0,0 +1,201 @@
+package wordfeud
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Event is an event delivered by a Watcher. It is one of OpponentMoved, ChatReceived, InviteReceived,
+// GameFinished or WatchFailed.
+type Event interface {
+	event()
+}
+
+// OpponentMoved is delivered when an opponent has made a move, passed, swapped or resigned.
+type OpponentMoved struct {
+	Game Game
+	Move Move
+}
+
+// ChatReceived is delivered when new chat messages have arrived in a game.
+type ChatReceived struct {
+	Game GameID
+	// New is the number of messages that have arrived since the last poll.
+	New int
+	// Unread is the total number of unread messages.
+	Unread int
+}
+
+// InviteReceived is delivered when the user has been invited to a game.
+type InviteReceived struct {
+	Invitation Invitation
+}
+
+// GameFinished is delivered when a game has ended.
+type GameFinished struct {
+	Game Game
+}
+
+// WatchFailed is delivered when polling fails. The watcher keeps polling after delivering it.
+type WatchFailed struct {
+	Err error
+}
+
+func (OpponentMoved) event()  {}
+func (ChatReceived) event()   {}
+func (InviteReceived) event() {}
+func (GameFinished) event()   {}
+func (WatchFailed) event()    {}
+
+// Watcher polls the API for changes to the games and invitations of a user and delivers them as events.
+//
+// Each poll requests Client.Status, and only requests the games that have been updated since the previous
+// poll. The first poll records the current state without delivering any events. Games that appear after
+// the first poll, such as accepted invitations and random matches, are compared against an empty game, so
+// that a move already made by the opponent and unread chat messages are delivered too.
+type Watcher struct {
+	session  *Session
+	interval time.Duration
+
+	polled  bool
+	games   map[GameID]*watchedGame
+	invites map[InvitationID]bool
+}
+
+type watchedGame struct {
+	updated   Timestamp
+	moveCount int
+	chatCount int
+	isRunning bool
+}
+
+// NewWatcher returns a Watcher that polls on behalf of session every interval. It returns an error if
+// interval is not positive.
+func NewWatcher(session *Session, interval time.Duration) (*Watcher, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("watcher interval must be positive, got %v", interval)
+	}
+	return &Watcher{
+		session:  session,
+		interval: interval,
+		games:    make(map[GameID]*watchedGame),
+		invites:  make(map[InvitationID]bool),
+	}, nil
+}
+
+// Run polls until ctx is done, calling f with every event. f is called from the goroutine calling Run,
+// and polling is paused while it runs. Run returns the context error.
+func (w *Watcher) Run(ctx context.Context, f func(Event)) error {
+	t := time.NewTicker(w.interval)
+	defer t.Stop()
+
+	for {
+		for _, e := range w.Poll(ctx) {
+			f(e)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// Events starts polling in a new goroutine and returns a channel on which events are delivered. The channel
+// is closed when ctx is done.
+func (w *Watcher) Events(ctx context.Context) <-chan Event {
+	ch := make(chan Event)
+	go func() {
+		defer close(ch)
+		_ = w.Run(ctx, func(e Event) {
+			select {
+			case ch <- e:
+			case <-ctx.Done():
+			}
+		})
+	}()
+	return ch
+}
+
+// Poll polls once and returns the events that have occurred since the previous poll. It is used by Run,
+// but can also be called directly to poll on demand. Poll must not be called concurrently with itself
+// or Run.
+func (w *Watcher) Poll(ctx context.Context) []Event {
+	status, err := w.session.Status(ctx)
+	if err != nil {
+		return []Event{WatchFailed{Err: err}}
+	}
+
+	var events []Event
+	current := make(map[GameID]bool, len(status.Games))
+	for _, gs := range status.Games {
+		current[gs.ID] = true
+		prev, ok := w.games[gs.ID]
+		if ok && gs.Updated.Equal(prev.updated.Time) {
+			continue
+		}
+
+		g, err := w.session.Game(ctx, gs.ID)
+		if err != nil {
+			events = append(events, WatchFailed{Err: err})
+			continue
+		}
+		w.games[gs.ID] = &watchedGame{
+			updated:   gs.Updated,
+			moveCount: g.MoveCount,
+			chatCount: g.ChatCount,
+			isRunning: g.IsRunning,
+		}
+		if !w.polled {
+			continue
+		}
+		if !ok {
+			prev = &watchedGame{isRunning: true}
+		}
+
+		if g.MoveCount > prev.moveCount && g.LastMove != nil && !isLocalPlayer(g, g.LastMove.UserID) {
+			events = append(events, OpponentMoved{Game: *g, Move: *g.LastMove})
+		}
+		if g.ChatCount > prev.chatCount && g.ChatCount > g.ReadChatCount {
+			events = append(events, ChatReceived{
+				Game:   g.ID,
+				New:    g.ChatCount - prev.chatCount,
+				Unread: g.ChatCount - g.ReadChatCount,
+			})
+		}
+		if prev.isRunning && !g.IsRunning {
+			events = append(events, GameFinished{Game: *g})
+		}
+	}
+
+	// Forget games that are no longer listed, such as games that have been deleted.
+	for id := range w.games {
+		if !current[id] {
+			delete(w.games, id)
+		}
+	}
+
+	invites := make(map[InvitationID]bool, len(status.InvitesReceived))
+	for _, inv := range status.InvitesReceived {
+		invites[inv.ID] = true
+		if w.polled && !w.invites[inv.ID] {
+			events = append(events, InviteReceived{Invitation: inv})
+		}
+	}
+	w.invites = invites
+
+	w.polled = true
+	return events
+}
+
+// isLocalPlayer reports whether user is the player on whose behalf g was requested.
+func isLocalPlayer(g *Game, user UserID) bool {
+	for _, p := range g.Players {
+		if p.ID == user {
+			return p.IsLocal
+		}
+	}
+	return false
+}
@@ -0,0 +1,161 @@
+package wordfeud_test
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/kayex/wordfeud"
+	"github.com/kayex/wordfeud/wordfeudtest"
+)
+
+func TestNewWatcherInterval(t *testing.T) {
+	tests := []struct {
+		interval time.Duration
+		wantErr  bool
+	}{
+		{-time.Second, true},
+		{0, true},
+		{time.Nanosecond, false},
+		{time.Minute, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.interval.String(), func(t *testing.T) {
+			w, err := wordfeud.NewWatcher(nil, tt.interval)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if err == nil && w == nil {
+				t.Error("NewWatcher returned neither a watcher nor an error")
+			}
+		})
+	}
+}
+
+// describeEvent returns a short description of e, identifying the game it concerns by its position in games.
+func describeEvent(e wordfeud.Event, games []wordfeud.GameID) string {
+	game := func(id wordfeud.GameID) int {
+		return slices.Index(games, id)
+	}
+	switch e := e.(type) {
+	case wordfeud.OpponentMoved:
+		return fmt.Sprintf("game %d: opponent moved %s", game(e.Game.ID), e.Move.MoveType)
+	case wordfeud.ChatReceived:
+		return fmt.Sprintf("game %d: %d new, %d unread", game(e.Game), e.New, e.Unread)
+	case wordfeud.InviteReceived:
+		return "invited by " + e.Invitation.Inviter
+	case wordfeud.GameFinished:
+		return fmt.Sprintf("game %d: finished", game(e.Game.ID))
+	case wordfeud.WatchFailed:
+		return fmt.Sprintf("failed: %v", e.Err)
+	}
+	return fmt.Sprintf("unknown event %T", e)
+}
+
+func TestWatcherPoll(t *testing.T) {
+	server := wordfeudtest.NewServer(wordfeudtest.WithSeed(1))
+	defer server.Close()
+	a := server.AddUser("alice", "alice@example.com", "alice password")
+	b := server.AddUser("bob", "bob@example.com", "bob password")
+	games := []wordfeud.GameID{server.StartGame(wordfeud.RuleSetEnglish, wordfeud.BoardNormal, a, b)}
+
+	client := server.Client()
+	ctx := context.Background()
+	alice, err := client.LoginWithEmail(ctx, "alice@example.com", "alice password")
+	if err != nil {
+		t.Fatalf("logging in alice: %v", err)
+	}
+	bob, err := client.LoginWithEmail(ctx, "bob@example.com", "bob password")
+	if err != nil {
+		t.Fatalf("logging in bob: %v", err)
+	}
+	s := wordfeud.NewSession(client, wordfeud.Credentials{Email: "bob@example.com", Password: "bob password"})
+	w, err := wordfeud.NewWatcher(s, time.Second)
+	if err != nil {
+		t.Fatalf("NewWatcher returned error: %v", err)
+	}
+
+	// Each step changes the games of bob, and is followed by a poll of the watcher of bob.
+	steps := []struct {
+		name   string
+		change func() error
+		want   []string
+	}{
+		{
+			name: "first poll",
+			change: func() error {
+				_, err := client.SendChatMessage(ctx, alice, games[0], "Hi")
+				return err
+			},
+			// The unread message was sent before the first poll, so it is not delivered.
+		},
+		{
+			name:   "nothing changed",
+			change: func() error { return nil },
+		},
+		{
+			name: "opponent moved and chatted",
+			change: func() error {
+				_, err := client.Pass(ctx, alice, games[0])
+				if err != nil {
+					return err
+				}
+				_, err = client.SendChatMessage(ctx, alice, games[0], "Your turn")
+				return err
+			},
+			want: []string{
+				"game 0: opponent moved pass",
+				"game 0: 1 new, 2 unread",
+			},
+		},
+		{
+			name: "own move",
+			change: func() error {
+				_, err := client.Pass(ctx, bob, games[0])
+				return err
+			},
+		},
+		{
+			name: "invited",
+			change: func() error {
+				_, err := client.Invite(ctx, alice, "bob", wordfeud.RuleSetEnglish, wordfeud.BoardNormal)
+				return err
+			},
+			want: []string{"invited by alice"},
+		},
+		{
+			name: "new game with a move already made",
+			change: func() error {
+				games = append(games, server.StartGame(wordfeud.RuleSetEnglish, wordfeud.BoardNormal, a, b))
+				_, err := client.Pass(ctx, alice, games[1])
+				return err
+			},
+			want: []string{"game 1: opponent moved pass"},
+		},
+		{
+			name: "resigned",
+			change: func() error {
+				_, err := client.Resign(ctx, bob, games[0])
+				return err
+			},
+			want: []string{"game 0: finished"},
+		},
+	}
+
+	for _, step := range steps {
+		err := step.change()
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		var got []string
+		for _, e := range w.Poll(ctx) {
+			got = append(got, describeEvent(e, games))
+		}
+		if !slices.Equal(got, step.want) {
+			t.Errorf("%s: got events %q, want %q", step.name, got, step.want)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package wordfeud
 
 import (
 	"encoding/json"
+	"fmt"
 	"math"
 	"time"
 )
@@ -45,15 +46,20 @@ type MoveType string
 const (
 	MoveTypeMove   MoveType = "move"
 	MoveTypePass   MoveType = "pass"
+	MoveTypeSwap   MoveType = "swap"
 	MoveTypeResign MoveType = "resign"
 )
 
+// Move is a move made in a game. Move, MainWord and Points are set for MoveTypeMove moves. Swaps do not
+// reveal the exchanged letters, so TileCount holds the number of tiles exchanged by a MoveTypeSwap move
+// instead.
 type Move struct {
-	MoveType MoveType    `json:"move_type"`
-	UserID   UserID      `json:"user_id"`
-	Move     []Placement `json:"move"`
-	MainWord *string     `json:"main_word"`
-	Points   *int        `json:"points"`
+	MoveType  MoveType    `json:"move_type"`
+	UserID    UserID      `json:"user_id"`
+	Move      []Placement `json:"move"`
+	MainWord  *string     `json:"main_word"`
+	Points    *int        `json:"points"`
+	TileCount *int        `json:"tile_count"`
 }
 
 type Placement struct {
@@ -71,6 +77,14 @@ func (p *Placement) Array() [4]any {
 	return [4]any{p.Column, p.Row, p.Letter, p.Blank}
 }
 
+// MarshalJSON encodes p as the [column, row, letter, blank] array used by the API, so that values such
+// as Game and Move encode to the same JSON they are decoded from.
+func (p Placement) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.Array())
+}
+
+// UnmarshalJSON decodes a [column, row, letter, blank] array. An array with elements of the wrong type
+// is reported as an error.
 func (p *Placement) UnmarshalJSON(b []byte) error {
 	var a [4]any
 	err := json.Unmarshal(b, &a)
@@ -78,12 +92,15 @@ func (p *Placement) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
-	*p = Placement{
-		int(a[0].(float64)),
-		int(a[1].(float64)),
-		a[2].(string),
-		a[3].(bool),
+	column, ok1 := a[0].(float64)
+	row, ok2 := a[1].(float64)
+	letter, ok3 := a[2].(string)
+	blank, ok4 := a[3].(bool)
+	if !ok1 || !ok2 || !ok3 || !ok4 {
+		return fmt.Errorf("invalid placement: %s", b)
 	}
+
+	*p = Placement{int(column), int(row), letter, blank}
 	return nil
 }
 
@@ -92,7 +109,7 @@ func uniqueSquares(placements []Placement) bool {
 		column int
 		row    int
 	}
-	positions := make([]position, len(placements))
+	positions := make([]position, 0, len(placements))
 
 	for _, pl := range placements {
 		for _, pos := range positions {
@@ -156,8 +173,10 @@ const (
 type Ruleset struct {
 	Ruleset      RulesetID      `json:"ruleset"`
 	LanguageCode string         `json:"language_code"`
+	Alphabet     []string       `json:"alphabet"`
 	TilePoints   map[string]int `json:"tile_points"`
 	TileCounts   map[string]int `json:"tile_counts"`
+	Blanks       int            `json:"blanks"`
 }
 
 type UserID int64
@@ -196,8 +215,10 @@ type Relationship struct {
 	GamesTied     int       `json:"games_tied"`
 }
 
+// Message is a chat message sent in a game. Like every other time in the API, Sent is encoded as Unix
+// seconds, so it is a Timestamp rather than a time.Time. Use Sent.Time to get the time.Time.
 type Message struct {
-	Sent    time.Time `json:"sent"`
+	Sent    Timestamp `json:"sent"`
 	Sender  UserID    `json:"sender"`
 	Message string    `json:"message"`
 }
@@ -242,12 +263,25 @@ type Timestamp struct {
 	time.Time
 }
 
+// MarshalJSON encodes t as fractional Unix seconds, like the API does. The zero Timestamp is encoded as 0.
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("0"), nil
+	}
+	return json.Marshal(float64(t.Unix()) + float64(t.Nanosecond())/1e9)
+}
+
+// UnmarshalJSON decodes fractional Unix seconds. 0 is decoded as the zero Timestamp.
 func (t *Timestamp) UnmarshalJSON(b []byte) error {
 	var f float64
 	err := json.Unmarshal(b, &f)
 	if err != nil {
 		return err
 	}
+	if f == 0 {
+		*t = Timestamp{}
+		return nil
+	}
 	sec, dec := math.Modf(f)
 	*t = Timestamp{time.Unix(int64(sec), int64(dec*(1e9)))}
 	return nil
@@ -0,0 +1,70 @@
+package wordfeud
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimestampJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		time Timestamp
+		json string
+	}{
+		{"zero", Timestamp{}, "0"},
+		{"whole seconds", Timestamp{time.Unix(1700000000, 0)}, "1700000000"},
+		{"fractional seconds", Timestamp{time.Unix(1700000000, 250000000)}, "1700000000.25"},
+		{"far future", Timestamp{time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC)}, "32503680000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(tt.time)
+			if err != nil {
+				t.Fatalf("Marshal returned error: %v", err)
+			}
+			if string(b) != tt.json {
+				t.Errorf("got %s, want %s", b, tt.json)
+			}
+
+			var got Timestamp
+			err = json.Unmarshal(b, &got)
+			if err != nil {
+				t.Fatalf("Unmarshal returned error: %v", err)
+			}
+			if got.IsZero() != tt.time.IsZero() || got.Sub(tt.time.Time).Abs() > time.Microsecond {
+				t.Errorf("got %v after round trip, want %v", got, tt.time)
+			}
+		})
+	}
+}
+
+func TestPlacementJSON(t *testing.T) {
+	p := Place(3, 12, "Å", true)
+	b, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("Marshal returned error: %v", err)
+	}
+	if string(b) != `[3,12,"Å",true]` {
+		t.Errorf("got %s, want [3,12,\"Å\",true]", b)
+	}
+
+	var got Placement
+	err = json.Unmarshal(b, &got)
+	if err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if got != p {
+		t.Errorf("got %+v after round trip, want %+v", got, p)
+	}
+}
+
+func TestPlacementUnmarshalJSONInvalid(t *testing.T) {
+	for _, s := range []string{`[3,12,"A"]`, `["3",12,"A",false]`, `[3,12,7,false]`, `[3,12,"A","no"]`, `{}`} {
+		var p Placement
+		if err := json.Unmarshal([]byte(s), &p); err == nil {
+			t.Errorf("Unmarshal(%s) returned no error", s)
+		}
+	}
+}
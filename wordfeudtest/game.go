@@ -0,0 +1,369 @@
+package wordfeudtest
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/kayex/wordfeud"
+)
+
+// maxPasses is the number of consecutive turns without tiles being placed after which a game ends.
+const maxPasses = 6
+
+type game struct {
+	id        wordfeud.GameID
+	ruleset   *wordfeud.Ruleset
+	boardID   wordfeud.BoardID
+	board     *wordfeud.BoardState
+	players   [2]*player
+	current   int
+	bag       []string
+	moveCount int
+	passCount int
+	lastMove  *wordfeud.Move
+	isRunning bool
+	created   time.Time
+	updated   time.Time
+	chat      []wordfeud.Message
+}
+
+type player struct {
+	id            wordfeud.UserID
+	score         int
+	rack          []string
+	readChatCount int
+}
+
+func (s *Server) newGame(ruleset wordfeud.RulesetID, board wordfeud.BoardID, first, second wordfeud.UserID) (*game, error) {
+	rs, ok := wordfeud.BuiltinRuleset(ruleset)
+	if !ok {
+		return nil, wordfeud.ErrInvalidRuleset
+	}
+	grid, err := s.grid(board)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	g := &game{
+		id:        wordfeud.GameID(s.newID()),
+		ruleset:   rs,
+		boardID:   board,
+		board:     wordfeud.NewBoardState(grid, nil),
+		players:   [2]*player{{id: first}, {id: second}},
+		isRunning: true,
+		created:   now,
+		updated:   now,
+	}
+	for _, l := range rs.Alphabet {
+		for range rs.TileCounts[l] {
+			g.bag = append(g.bag, l)
+		}
+	}
+	for range rs.Blanks {
+		g.bag = append(g.bag, wordfeud.BlankTile)
+	}
+	s.rand.Shuffle(len(g.bag), func(i, j int) {
+		g.bag[i], g.bag[j] = g.bag[j], g.bag[i]
+	})
+	for _, p := range g.players {
+		p.rack = g.draw(wordfeud.RackSize)
+	}
+
+	s.games[g.id] = g
+	return g, nil
+}
+
+func (s *Server) grid(board wordfeud.BoardID) (wordfeud.Grid, error) {
+	switch board {
+	case wordfeud.BoardNormal:
+		return wordfeud.NormalGrid, nil
+	case wordfeud.BoardRandom:
+		return s.randomGrid, nil
+	default:
+		return wordfeud.Grid{}, wordfeud.ErrInvalidBoardType
+	}
+}
+
+// shuffleGrid returns a grid with the premium squares of wordfeud.NormalGrid in random positions, except
+// for the center square which is always left without a premium.
+func (s *Server) shuffleGrid() wordfeud.Grid {
+	var squares []wordfeud.Square
+	for row := range wordfeud.BoardSize {
+		for column := range wordfeud.BoardSize {
+			if column != wordfeud.Center || row != wordfeud.Center {
+				squares = append(squares, wordfeud.NormalGrid.Square(column, row))
+			}
+		}
+	}
+	s.rand.Shuffle(len(squares), func(i, j int) {
+		squares[i], squares[j] = squares[j], squares[i]
+	})
+
+	var grid wordfeud.Grid
+	for row := range wordfeud.BoardSize {
+		for column := range wordfeud.BoardSize {
+			if column != wordfeud.Center || row != wordfeud.Center {
+				grid[row][column], squares = squares[0], squares[1:]
+			}
+		}
+	}
+	return grid
+}
+
+func (g *game) player(id wordfeud.UserID) *player {
+	for _, p := range g.players {
+		if p.id == id {
+			return p
+		}
+	}
+	return nil
+}
+
+// draw removes up to n tiles from the bag and returns them.
+func (g *game) draw(n int) []string {
+	n = min(n, len(g.bag))
+	tiles := slices.Clone(g.bag[:n])
+	g.bag = g.bag[n:]
+	return tiles
+}
+
+// turn checks that it is the turn of user to move.
+func (g *game) turn(user wordfeud.UserID) (*player, error) {
+	if !g.isRunning {
+		return nil, wordfeud.ErrGameOver
+	}
+	p := g.players[g.current]
+	if p.id != user {
+		return nil, wordfeud.ErrNotYourTurn
+	}
+	return p, nil
+}
+
+// takeTiles removes tiles from the rack of p, where blank tiles are represented by wordfeud.BlankTile.
+// The rack is left untouched if any of the tiles are missing.
+func takeTiles(p *player, tiles []string) error {
+	rack := slices.Clone(p.rack)
+	for _, t := range tiles {
+		i := slices.Index(rack, t)
+		if i < 0 {
+			return wordfeud.ErrIllegalTiles
+		}
+		rack = slices.Delete(rack, i, i+1)
+	}
+	p.rack = rack
+	return nil
+}
+
+func (g *game) move(s *Server, user wordfeud.UserID, move []wordfeud.Placement) (*wordfeud.MoveResult, error) {
+	p, err := g.turn(user)
+	if err != nil {
+		return nil, err
+	}
+
+	score, err := g.board.Score(move, g.ruleset)
+	if err != nil {
+		return nil, err
+	}
+	if s.words != nil {
+		for _, w := range score.Words() {
+			if !s.words.Contains(w.Word.String()) {
+				return nil, fmt.Errorf("%w: %s", wordfeud.ErrIllegalWord, w.Word)
+			}
+		}
+	}
+
+	var tiles []string
+	for _, pl := range move {
+		if pl.Blank {
+			tiles = append(tiles, wordfeud.BlankTile)
+		} else {
+			tiles = append(tiles, pl.Letter)
+		}
+	}
+	err = takeTiles(p, tiles)
+	if err != nil {
+		return nil, err
+	}
+
+	g.board.Place(move...)
+	p.score += score.Total
+	newTiles := g.draw(len(move))
+	p.rack = append(p.rack, newTiles...)
+	g.passCount = 0
+
+	mainWord := score.MainWord.Word.String()
+	g.endTurn(&wordfeud.Move{
+		MoveType: wordfeud.MoveTypeMove,
+		UserID:   user,
+		Move:     move,
+		MainWord: &mainWord,
+		Points:   &score.Total,
+	})
+	if len(p.rack) == 0 {
+		g.finish(p)
+	}
+	return g.result(s, user, newTiles, score.Total, &mainWord), nil
+}
+
+func (g *game) pass(s *Server, user wordfeud.UserID) (*wordfeud.MoveResult, error) {
+	_, err := g.turn(user)
+	if err != nil {
+		return nil, err
+	}
+
+	g.passCount++
+	g.endTurn(&wordfeud.Move{MoveType: wordfeud.MoveTypePass, UserID: user})
+	if g.passCount >= maxPasses {
+		g.finish(nil)
+	}
+	return g.result(s, user, nil, 0, nil), nil
+}
+
+func (g *game) swap(s *Server, user wordfeud.UserID, tiles []string) (*wordfeud.MoveResult, error) {
+	p, err := g.turn(user)
+	if err != nil {
+		return nil, err
+	}
+	if len(tiles) == 0 || len(g.bag) < wordfeud.RackSize {
+		return nil, wordfeud.ErrIllegalTiles
+	}
+	err = takeTiles(p, tiles)
+	if err != nil {
+		return nil, err
+	}
+
+	newTiles := g.draw(len(tiles))
+	p.rack = append(p.rack, newTiles...)
+	g.bag = append(g.bag, tiles...)
+	s.rand.Shuffle(len(g.bag), func(i, j int) {
+		g.bag[i], g.bag[j] = g.bag[j], g.bag[i]
+	})
+
+	g.passCount++
+	count := len(tiles)
+	g.endTurn(&wordfeud.Move{MoveType: wordfeud.MoveTypeSwap, UserID: user, TileCount: &count})
+	if g.passCount >= maxPasses {
+		g.finish(nil)
+	}
+	return g.result(s, user, newTiles, 0, nil), nil
+}
+
+func (g *game) resign(s *Server, user wordfeud.UserID) (*wordfeud.MoveResult, error) {
+	if !g.isRunning {
+		return nil, wordfeud.ErrGameOver
+	}
+
+	g.endTurn(&wordfeud.Move{MoveType: wordfeud.MoveTypeResign, UserID: user})
+	g.isRunning = false
+	return g.result(s, user, nil, 0, nil), nil
+}
+
+func (g *game) endTurn(m *wordfeud.Move) {
+	g.lastMove = m
+	g.moveCount++
+	g.current = 1 - g.current
+	g.updated = time.Now()
+}
+
+// finish ends the game. The value of the tiles left on each rack is subtracted from the score of its
+// player and, if a player has emptied their rack, added to the score of that player.
+func (g *game) finish(finisher *player) {
+	for _, p := range g.players {
+		left := 0
+		for _, t := range p.rack {
+			left += g.ruleset.Points(t, t == wordfeud.BlankTile)
+		}
+		p.score -= left
+		if finisher != nil {
+			finisher.score += left
+		}
+	}
+	g.isRunning = false
+}
+
+func (g *game) result(s *Server, user wordfeud.UserID, newTiles []string, points int, mainWord *string) *wordfeud.MoveResult {
+	r := &wordfeud.MoveResult{
+		MainWord:  mainWord,
+		NewTiles:  newTiles,
+		IsRunning: g.isRunning,
+		Updated:   wordfeud.Timestamp{Time: g.updated},
+		Game:      g.view(s, user),
+	}
+	if mainWord != nil {
+		r.Points = &points
+	}
+	if r.NewTiles == nil {
+		r.NewTiles = []string{}
+	}
+	return r
+}
+
+// view returns the game as seen by user, who can only see their own rack.
+func (g *game) view(s *Server, user wordfeud.UserID) wordfeud.Game {
+	v := wordfeud.Game{
+		ID:            g.id,
+		Board:         g.boardID,
+		Ruleset:       g.ruleset.Ruleset,
+		MoveCount:     g.moveCount,
+		IsRunning:     g.isRunning,
+		EndGame:       int(g.endGameStatus(user)),
+		Created:       wordfeud.Timestamp{Time: g.created},
+		Updated:       wordfeud.Timestamp{Time: g.updated},
+		CurrentPlayer: wordfeud.PlayerPosition(g.current),
+		LastMove:      g.lastMove,
+		ChatCount:     len(g.chat),
+		SeenFinished:  false,
+		Tiles:         g.board.Tiles(),
+		BagCount:      len(g.bag),
+		PassCount:     g.passCount,
+	}
+	for i, p := range g.players {
+		vp := wordfeud.Player{
+			ID:       p.id,
+			Score:    p.score,
+			Position: wordfeud.PlayerPosition(i),
+			IsLocal:  p.id == user,
+		}
+		if u, ok := s.users[p.id]; ok {
+			vp.Username = u.username
+			vp.AvatarUpdated = wordfeud.Timestamp{Time: u.avatarUpdated}
+		}
+		if p.id == user {
+			vp.Rack = slices.Clone(p.rack)
+			v.ReadChatCount = p.readChatCount
+		}
+		v.Players = append(v.Players, vp)
+	}
+	return v
+}
+
+// tied reports whether the game ended with both players having the same score.
+func (g *game) tied() bool {
+	if g.isRunning || (g.lastMove != nil && g.lastMove.MoveType == wordfeud.MoveTypeResign) {
+		return false
+	}
+	return g.players[0].score == g.players[1].score
+}
+
+func (g *game) endGameStatus(user wordfeud.UserID) wordfeud.EndGameStatus {
+	if g.isRunning {
+		return wordfeud.EndGameStatusNotFinished
+	}
+	me := g.player(user)
+	other := g.players[0]
+	if other == me {
+		other = g.players[1]
+	}
+	if g.lastMove != nil && g.lastMove.MoveType == wordfeud.MoveTypeResign {
+		if g.lastMove.UserID == user {
+			return wordfeud.EndGameStatusLoss
+		}
+		return wordfeud.EndGameStatusWin
+	}
+	if me.score >= other.score {
+		return wordfeud.EndGameStatusWin
+	}
+	return wordfeud.EndGameStatusLoss
+}
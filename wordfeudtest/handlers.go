@@ -0,0 +1,523 @@
+package wordfeudtest
+
+import (
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/kayex/wordfeud"
+)
+
+func (s *Server) createAccount(w http.ResponseWriter, r *http.Request, _ *user) (any, error) {
+	var req struct {
+		Username string `json:"username"`
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := s.addUser(req.Username, req.Email, req.Password)
+	if err != nil {
+		return nil, err
+	}
+	setSessionCookie(w, s.newSession(u))
+	return u.login(), nil
+}
+
+func (s *Server) loginWithEmail(w http.ResponseWriter, r *http.Request, _ *user) (any, error) {
+	var req struct {
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, u := range s.users {
+		if u.email == req.Email {
+			return s.login(w, u, req.Password)
+		}
+	}
+	return nil, wordfeud.ErrUnknownEmail
+}
+
+func (s *Server) loginWithID(w http.ResponseWriter, r *http.Request, _ *user) (any, error) {
+	var req struct {
+		ID       wordfeud.UserID `json:"id"`
+		Password string          `json:"password"`
+	}
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	u, ok := s.users[req.ID]
+	if !ok {
+		return nil, wordfeud.ErrUserNotFound
+	}
+	return s.login(w, u, req.Password)
+}
+
+func (s *Server) login(w http.ResponseWriter, u *user, passwordHash string) (any, error) {
+	if u.passwordHash != passwordHash {
+		return nil, wordfeud.ErrWrongPassword
+	}
+	setSessionCookie(w, s.newSession(u))
+	return u.login(), nil
+}
+
+func setSessionCookie(w http.ResponseWriter, session wordfeud.SessionID) {
+	http.SetCookie(w, &http.Cookie{Name: "sessionid", Value: string(session), Path: "/"})
+}
+
+func (s *Server) changePassword(_ http.ResponseWriter, r *http.Request, u *user) (any, error) {
+	var req struct {
+		Password string `json:"password"`
+	}
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	u.passwordHash = req.Password
+	return struct{}{}, nil
+}
+
+func (s *Server) updateAvatar(_ http.ResponseWriter, r *http.Request, u *user) (any, error) {
+	var req struct {
+		ImageData string `json:"image_data"`
+	}
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	u.avatarUpdated = time.Now()
+	return struct {
+		AvatarUpdated wordfeud.Timestamp `json:"avatar_updated"`
+	}{wordfeud.Timestamp{Time: u.avatarUpdated}}, nil
+}
+
+func (s *Server) listRelationships(_ http.ResponseWriter, _ *http.Request, u *user) (any, error) {
+	return struct {
+		Relationships []wordfeud.Relationship `json:"relationships"`
+	}{s.relationships[u.id]}, nil
+}
+
+func (s *Server) createRelationship(_ http.ResponseWriter, r *http.Request, u *user) (any, error) {
+	var req struct {
+		ID   wordfeud.UserID `json:"id"`
+		Type int             `json:"type"`
+	}
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	friend, ok := s.users[req.ID]
+	if !ok {
+		return nil, wordfeud.ErrUserNotFound
+	}
+	if friend.id == u.id {
+		return nil, wordfeud.ErrIllegalUserSelf
+	}
+	for _, rel := range s.relationships[u.id] {
+		if rel.UserID == friend.id {
+			return nil, wordfeud.ErrAlreadyExists
+		}
+	}
+
+	rel := wordfeud.Relationship{
+		UserID:        friend.id,
+		Username:      friend.username,
+		AvatarUpdated: wordfeud.Timestamp{Time: friend.avatarUpdated},
+		Type:          req.Type,
+	}
+	for _, g := range s.games {
+		if g.player(u.id) == nil || g.player(friend.id) == nil || g.isRunning {
+			continue
+		}
+		switch {
+		case g.tied():
+			rel.GamesTied++
+		case g.endGameStatus(u.id) == wordfeud.EndGameStatusWin:
+			rel.GamesWon++
+		default:
+			rel.GamesLost++
+		}
+	}
+	s.relationships[u.id] = append(s.relationships[u.id], rel)
+	return rel, nil
+}
+
+func (s *Server) deleteRelationship(_ http.ResponseWriter, r *http.Request, u *user) (any, error) {
+	id, err := pathID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	rels := s.relationships[u.id]
+	i := slices.IndexFunc(rels, func(rel wordfeud.Relationship) bool {
+		return rel.UserID == wordfeud.UserID(id)
+	})
+	if i < 0 {
+		return nil, wordfeud.ErrNotFound
+	}
+	s.relationships[u.id] = slices.Delete(rels, i, i+1)
+	return struct{}{}, nil
+}
+
+func (s *Server) listGames(_ http.ResponseWriter, _ *http.Request, u *user) (any, error) {
+	games := []wordfeud.Game{}
+	for _, g := range s.userGames(u) {
+		games = append(games, g.view(s, u.id))
+	}
+	return struct {
+		Games []wordfeud.Game `json:"games"`
+	}{games}, nil
+}
+
+func (s *Server) status(_ http.ResponseWriter, _ *http.Request, u *user) (any, error) {
+	status := wordfeud.Status{
+		Games:           []wordfeud.GameStatus{},
+		InvitesSent:     []wordfeud.Invitation{},
+		InvitesReceived: []wordfeud.Invitation{},
+		RandomRequests:  []wordfeud.Invitation{},
+	}
+	for _, g := range s.userGames(u) {
+		status.Games = append(status.Games, wordfeud.GameStatus{
+			ID:            g.id,
+			ChatCount:     len(g.chat),
+			Updated:       wordfeud.Timestamp{Time: g.updated},
+			ReadChatCount: g.player(u.id).readChatCount,
+		})
+	}
+	for _, inv := range s.sortedInvitations() {
+		if inv.InviterID == u.id {
+			status.InvitesSent = append(status.InvitesSent, *inv)
+		}
+		if inv.InviteeID == u.id {
+			status.InvitesReceived = append(status.InvitesReceived, *inv)
+		}
+	}
+	for _, inv := range s.randomQueue {
+		if inv.InviterID == u.id {
+			status.RandomRequests = append(status.RandomRequests, *inv)
+		}
+	}
+	return status, nil
+}
+
+// userGames returns the games u is playing, ordered by id.
+func (s *Server) userGames(u *user) []*game {
+	var games []*game
+	for _, g := range s.games {
+		if g.player(u.id) != nil {
+			games = append(games, g)
+		}
+	}
+	slices.SortFunc(games, func(a, b *game) int {
+		return int(a.id - b.id)
+	})
+	return games
+}
+
+func (s *Server) sortedInvitations() []*wordfeud.Invitation {
+	var invitations []*wordfeud.Invitation
+	for _, inv := range s.invitations {
+		invitations = append(invitations, inv)
+	}
+	slices.SortFunc(invitations, func(a, b *wordfeud.Invitation) int {
+		return int(a.ID - b.ID)
+	})
+	return invitations
+}
+
+func (s *Server) game(_ http.ResponseWriter, r *http.Request, u *user) (any, error) {
+	g, err := s.userGame(r, u)
+	if err != nil {
+		return nil, err
+	}
+	return struct {
+		Game wordfeud.Game `json:"game"`
+	}{g.view(s, u.id)}, nil
+}
+
+// userGame returns the game identified by the request path, which must be played by u.
+func (s *Server) userGame(r *http.Request, u *user) (*game, error) {
+	id, err := pathID(r)
+	if err != nil {
+		return nil, err
+	}
+	g, ok := s.games[wordfeud.GameID(id)]
+	if !ok {
+		return nil, wordfeud.ErrNotFound
+	}
+	if g.player(u.id) == nil {
+		return nil, wordfeud.ErrAccessDenied
+	}
+	return g, nil
+}
+
+func (s *Server) invite(_ http.ResponseWriter, r *http.Request, u *user) (any, error) {
+	var req struct {
+		Invitee   string             `json:"invitee"`
+		Ruleset   wordfeud.RulesetID `json:"ruleset"`
+		BoardType string             `json:"board_type"`
+	}
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	board, err := parseBoardType(req.BoardType)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := wordfeud.BuiltinRuleset(req.Ruleset); !ok {
+		return nil, wordfeud.ErrInvalidRuleset
+	}
+
+	var invitee *user
+	for _, other := range s.users {
+		if other.username == req.Invitee {
+			invitee = other
+		}
+	}
+	if invitee == nil {
+		return nil, wordfeud.ErrUserNotFound
+	}
+	if invitee.id == u.id {
+		return nil, wordfeud.ErrIllegalUserSelf
+	}
+	for _, inv := range s.invitations {
+		if inv.InviterID == u.id && inv.InviteeID == invitee.id {
+			return nil, wordfeud.ErrDuplicateInvite
+		}
+	}
+
+	inv := &wordfeud.Invitation{
+		ID:        wordfeud.InvitationID(s.newID()),
+		Inviter:   u.username,
+		InviterID: u.id,
+		Invitee:   invitee.username,
+		InviteeID: invitee.id,
+		BoardType: board,
+		Ruleset:   req.Ruleset,
+		Sent:      wordfeud.Timestamp{Time: time.Now()},
+	}
+	s.invitations[inv.ID] = inv
+	return struct {
+		Invitation wordfeud.Invitation `json:"invitation"`
+	}{*inv}, nil
+}
+
+func (s *Server) inviteRandomOpponent(_ http.ResponseWriter, r *http.Request, u *user) (any, error) {
+	var req struct {
+		Ruleset   wordfeud.RulesetID `json:"ruleset"`
+		BoardType string             `json:"board_type"`
+	}
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	board, err := parseBoardType(req.BoardType)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := wordfeud.BuiltinRuleset(req.Ruleset); !ok {
+		return nil, wordfeud.ErrInvalidRuleset
+	}
+
+	inv := &wordfeud.Invitation{
+		ID:        wordfeud.InvitationID(s.newID()),
+		Inviter:   u.username,
+		InviterID: u.id,
+		BoardType: board,
+		Ruleset:   req.Ruleset,
+		Sent:      wordfeud.Timestamp{Time: time.Now()},
+	}
+
+	// Pair up with a waiting request for the same kind of game, if there is one.
+	for i, waiting := range s.randomQueue {
+		if waiting.InviterID == u.id {
+			return nil, wordfeud.ErrDuplicateInvite
+		}
+		if waiting.Ruleset == inv.Ruleset && waiting.BoardType == inv.BoardType {
+			s.randomQueue = slices.Delete(s.randomQueue, i, i+1)
+			_, err = s.newGame(inv.Ruleset, inv.BoardType, waiting.InviterID, u.id)
+			if err != nil {
+				return nil, err
+			}
+			inv.Invitee = waiting.Inviter
+			inv.InviteeID = waiting.InviterID
+			return struct {
+				Invitation wordfeud.Invitation `json:"invitation"`
+			}{*inv}, nil
+		}
+	}
+
+	s.randomQueue = append(s.randomQueue, inv)
+	return struct {
+		Invitation wordfeud.Invitation `json:"invitation"`
+	}{*inv}, nil
+}
+
+func parseBoardType(s string) (wordfeud.BoardID, error) {
+	for _, b := range []wordfeud.BoardID{wordfeud.BoardNormal, wordfeud.BoardRandom} {
+		if b.String() == s {
+			return b, nil
+		}
+	}
+	return 0, wordfeud.ErrInvalidBoardType
+}
+
+func (s *Server) acceptInvitation(_ http.ResponseWriter, r *http.Request, u *user) (any, error) {
+	inv, err := s.receivedInvitation(r, u)
+	if err != nil {
+		return nil, err
+	}
+
+	g, err := s.newGame(inv.Ruleset, inv.BoardType, inv.InviterID, inv.InviteeID)
+	if err != nil {
+		return nil, err
+	}
+	delete(s.invitations, inv.ID)
+	return struct {
+		ID wordfeud.GameID `json:"id"`
+	}{g.id}, nil
+}
+
+func (s *Server) rejectInvitation(_ http.ResponseWriter, r *http.Request, u *user) (any, error) {
+	inv, err := s.receivedInvitation(r, u)
+	if err != nil {
+		return nil, err
+	}
+	delete(s.invitations, inv.ID)
+	return struct{}{}, nil
+}
+
+// receivedInvitation returns the invitation identified by the request path, which must have been
+// sent to u.
+func (s *Server) receivedInvitation(r *http.Request, u *user) (*wordfeud.Invitation, error) {
+	id, err := pathID(r)
+	if err != nil {
+		return nil, err
+	}
+	inv, ok := s.invitations[wordfeud.InvitationID(id)]
+	if !ok {
+		return nil, wordfeud.ErrNotFound
+	}
+	if inv.InviteeID != u.id {
+		return nil, wordfeud.ErrAccessDenied
+	}
+	return inv, nil
+}
+
+func (s *Server) move(_ http.ResponseWriter, r *http.Request, u *user) (any, error) {
+	var req struct {
+		Move []wordfeud.Placement `json:"move"`
+	}
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	g, err := s.userGame(r, u)
+	if err != nil {
+		return nil, err
+	}
+	return g.move(s, u.id, req.Move)
+}
+
+func (s *Server) pass(_ http.ResponseWriter, r *http.Request, u *user) (any, error) {
+	g, err := s.userGame(r, u)
+	if err != nil {
+		return nil, err
+	}
+	return g.pass(s, u.id)
+}
+
+func (s *Server) swap(_ http.ResponseWriter, r *http.Request, u *user) (any, error) {
+	var req struct {
+		Tiles []string `json:"tiles"`
+	}
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	g, err := s.userGame(r, u)
+	if err != nil {
+		return nil, err
+	}
+	return g.swap(s, u.id, req.Tiles)
+}
+
+func (s *Server) resign(_ http.ResponseWriter, r *http.Request, u *user) (any, error) {
+	g, err := s.userGame(r, u)
+	if err != nil {
+		return nil, err
+	}
+	return g.resign(s, u.id)
+}
+
+func (s *Server) chatMessages(_ http.ResponseWriter, r *http.Request, u *user) (any, error) {
+	g, err := s.userGame(r, u)
+	if err != nil {
+		return nil, err
+	}
+	g.player(u.id).readChatCount = len(g.chat)
+	return struct {
+		Messages []wordfeud.Message `json:"messages"`
+	}{append([]wordfeud.Message{}, g.chat...)}, nil
+}
+
+func (s *Server) sendChatMessage(_ http.ResponseWriter, r *http.Request, u *user) (any, error) {
+	var req struct {
+		Message string `json:"message"`
+	}
+	err := decode(r, &req)
+	if err != nil {
+		return nil, err
+	}
+	g, err := s.userGame(r, u)
+	if err != nil {
+		return nil, err
+	}
+
+	sent := wordfeud.Timestamp{Time: time.Now()}
+	g.chat = append(g.chat, wordfeud.Message{Sent: sent, Sender: u.id, Message: req.Message})
+	g.player(u.id).readChatCount = len(g.chat)
+	g.updated = sent.Time
+	return struct {
+		Sent wordfeud.Timestamp `json:"sent"`
+	}{sent}, nil
+}
+
+func (s *Server) board(_ http.ResponseWriter, r *http.Request, _ *user) (any, error) {
+	id, err := pathID(r)
+	if err != nil {
+		return nil, err
+	}
+	grid, err := s.grid(wordfeud.BoardID(id))
+	if err != nil {
+		return nil, err
+	}
+	return struct {
+		Board wordfeud.Grid `json:"board"`
+	}{grid}, nil
+}
+
+func (s *Server) ruleset(_ http.ResponseWriter, r *http.Request, _ *user) (any, error) {
+	id, err := pathID(r)
+	if err != nil {
+		return nil, err
+	}
+	rs, ok := wordfeud.BuiltinRuleset(wordfeud.RulesetID(id))
+	if !ok {
+		return nil, wordfeud.ErrInvalidRuleset
+	}
+	return struct {
+		Ruleset *wordfeud.Ruleset `json:"ruleset"`
+	}{rs}, nil
+}
@@ -0,0 +1,225 @@
+package wordfeudtest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"sync"
+)
+
+// Redacted replaces session ids and password hashes in recorded interactions.
+const Redacted = "REDACTED"
+
+// Recorder is an http.RoundTripper that records request and response pairs to a cassette file, or replays
+// them from one. Use it with wordfeud.WithHTTPClient to make deterministic tests against real API traffic.
+//
+// Session cookies and password hashes are redacted before interactions are written, so cassettes are safe
+// to commit. When replaying, clients receive the session id Redacted.
+type Recorder struct {
+	transport http.RoundTripper
+	replay    bool
+
+	mu           sync.Mutex
+	file         *os.File
+	w            *bufio.Writer
+	interactions []*interaction
+}
+
+// interaction is a single request and response pair, stored as one line of JSON in a cassette.
+type interaction struct {
+	Method       string      `json:"method"`
+	Path         string      `json:"path"`
+	RequestBody  string      `json:"request_body"`
+	StatusCode   int         `json:"status_code"`
+	Header       http.Header `json:"header"`
+	ResponseBody string      `json:"response_body"`
+
+	used bool
+}
+
+// NewRecorder returns a Recorder that sends requests using transport and records them to a new cassette
+// at path, truncating it if it already exists. If transport is nil, http.DefaultTransport is used.
+// The caller must call Close when finished, to flush the cassette.
+func NewRecorder(path string, transport http.RoundTripper) (*Recorder, error) {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("creating cassette: %v", err)
+	}
+	return &Recorder{
+		transport: transport,
+		file:      f,
+		w:         bufio.NewWriter(f),
+	}, nil
+}
+
+// NewReplayer returns a Recorder that replays the interactions in the cassette at path, without sending
+// any requests. Requests are matched to interactions by method, path and body, and each interaction is
+// replayed at most once, in the order they were recorded.
+func NewReplayer(path string) (*Recorder, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening cassette: %v", err)
+	}
+	defer f.Close()
+
+	r := &Recorder{replay: true}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 64*1024*1024)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var i interaction
+		err = json.Unmarshal(scanner.Bytes(), &i)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshalling interaction: %v", err)
+		}
+		r.interactions = append(r.interactions, &i)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading cassette: %v", err)
+	}
+	return r, nil
+}
+
+// Client returns an http.Client that uses r as its transport.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Close flushes and closes the cassette when recording. It does nothing when replaying.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.replay || r.file == nil {
+		return nil
+	}
+	err := r.w.Flush()
+	if err != nil {
+		return fmt.Errorf("writing cassette: %v", err)
+	}
+	err = r.file.Close()
+	r.file = nil
+	return err
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, fmt.Errorf("reading request body: %v", err)
+		}
+		err = req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("closing request body: %v", err)
+		}
+	}
+	redactedBody := redactBody(body)
+
+	if r.replay {
+		return r.replayInteraction(req, redactedBody)
+	}
+
+	out := req.Clone(req.Context())
+	out.Body = io.NopCloser(bytes.NewReader(body))
+	res, err := r.transport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	resBody, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("reading response body: %v", err)
+	}
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+
+	i := &interaction{
+		Method:       req.Method,
+		Path:         req.URL.Path,
+		RequestBody:  redactedBody,
+		StatusCode:   res.StatusCode,
+		Header:       redactHeader(res.Header),
+		ResponseBody: string(resBody),
+	}
+	b, err := json.Marshal(i)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling interaction: %v", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return nil, errors.New("recorder is closed")
+	}
+	_, err = r.w.Write(append(b, '\n'))
+	if err != nil {
+		return nil, fmt.Errorf("writing cassette: %v", err)
+	}
+	return res, nil
+}
+
+func (r *Recorder) replayInteraction(req *http.Request, body string) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, i := range r.interactions {
+		if i.used || i.Method != req.Method || i.Path != req.URL.Path || i.RequestBody != body {
+			continue
+		}
+		i.used = true
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", i.StatusCode, http.StatusText(i.StatusCode)),
+			StatusCode:    i.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        i.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewBufferString(i.ResponseBody)),
+			ContentLength: int64(len(i.ResponseBody)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("no recorded interaction for %s %s", req.Method, req.URL.Path)
+}
+
+var sessionCookieRegexp = regexp.MustCompile(`sessionid=[^;]*`)
+
+func redactHeader(h http.Header) http.Header {
+	h = h.Clone()
+	for _, key := range []string{"Set-Cookie", "Cookie"} {
+		for i, v := range h[key] {
+			h[key][i] = sessionCookieRegexp.ReplaceAllString(v, "sessionid="+Redacted)
+		}
+	}
+	return h
+}
+
+// redactBody replaces the password field of a JSON object request body.
+func redactBody(body []byte) string {
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(body, &fields) != nil {
+		return string(body)
+	}
+	if _, ok := fields["password"]; !ok {
+		return string(body)
+	}
+	fields["password"], _ = json.Marshal(Redacted)
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return string(body)
+	}
+	return string(b)
+}
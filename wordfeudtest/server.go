@@ -0,0 +1,357 @@
+// Package wordfeudtest provides an in-process fake of the Wordfeud API for testing code built on
+// wordfeud.Client.
+package wordfeudtest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	mrand "math/rand"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kayex/wordfeud"
+)
+
+// Server is a fake Wordfeud API server backed by an in-memory game engine. It implements the endpoints
+// used by wordfeud.Client, including the response envelope, the sessionid cookie and the error types of
+// the real API.
+//
+// Point a client at it using Server.Client, or wordfeud.WithBaseURL(server.URL).
+type Server struct {
+	*httptest.Server
+
+	mu            sync.Mutex
+	rand          *mrand.Rand
+	words         WordList
+	randomGrid    wordfeud.Grid
+	nextID        int64
+	users         map[wordfeud.UserID]*user
+	sessions      map[wordfeud.SessionID]wordfeud.UserID
+	games         map[wordfeud.GameID]*game
+	invitations   map[wordfeud.InvitationID]*wordfeud.Invitation
+	randomQueue   []*wordfeud.Invitation
+	relationships map[wordfeud.UserID][]wordfeud.Relationship
+}
+
+// WordList is a list of valid words. It is implemented by wordfeud.Dictionary and wordfeud.Lexicon.
+type WordList interface {
+	Contains(word string) bool
+}
+
+type ServerOption func(*Server)
+
+// WithWords makes the server reject moves forming words that are not in words with wordfeud.ErrIllegalWord.
+// By default, all words are accepted.
+func WithWords(words WordList) ServerOption {
+	return func(s *Server) {
+		s.words = words
+	}
+}
+
+// WithSeed sets the seed used to shuffle tile bags and random boards, making games deterministic.
+func WithSeed(seed int64) ServerOption {
+	return func(s *Server) {
+		s.rand = mrand.New(mrand.NewSource(seed))
+	}
+}
+
+// NewServer starts and returns a new Server. The caller should call Close when finished, to shut it down.
+func NewServer(opts ...ServerOption) *Server {
+	s := &Server{
+		rand:          mrand.New(mrand.NewSource(time.Now().UnixNano())),
+		nextID:        1,
+		users:         make(map[wordfeud.UserID]*user),
+		sessions:      make(map[wordfeud.SessionID]wordfeud.UserID),
+		games:         make(map[wordfeud.GameID]*game),
+		invitations:   make(map[wordfeud.InvitationID]*wordfeud.Invitation),
+		relationships: make(map[wordfeud.UserID][]wordfeud.Relationship),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.randomGrid = s.shuffleGrid()
+
+	s.Server = httptest.NewServer(s.routes())
+	return s
+}
+
+// Client returns a wordfeud.Client that sends its requests to s. Any opts are applied after the base URL
+// has been set.
+func (s *Server) Client(opts ...wordfeud.ClientOption) *wordfeud.Client {
+	return wordfeud.NewClient(append([]wordfeud.ClientOption{
+		wordfeud.WithHTTPClient(s.Server.Client()),
+		wordfeud.WithBaseURL(s.URL),
+	}, opts...)...)
+}
+
+type user struct {
+	id            wordfeud.UserID
+	username      string
+	email         string
+	passwordHash  string
+	created       time.Time
+	avatarUpdated time.Time
+}
+
+func (u *user) login() wordfeud.Login {
+	return wordfeud.Login{
+		Username:      u.username,
+		Email:         u.email,
+		ID:            u.id,
+		Cookies:       true,
+		Created:       wordfeud.Timestamp{Time: u.created},
+		AvatarUpdated: wordfeud.Timestamp{Time: u.avatarUpdated},
+	}
+}
+
+// AddUser creates a user account and returns its id. It panics if the username or email is already taken.
+func (s *Server) AddUser(username, email, password string) wordfeud.UserID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, err := s.addUser(username, email, wordfeud.HashPassword(password))
+	if err != nil {
+		panic(fmt.Sprintf("wordfeudtest: adding user %q: %v", username, err))
+	}
+	return u.id
+}
+
+func (s *Server) addUser(username, email, passwordHash string) (*user, error) {
+	for _, u := range s.users {
+		if u.username == username || u.email == email {
+			return nil, wordfeud.ErrAlreadyExists
+		}
+	}
+	now := time.Now()
+	u := &user{
+		id:            wordfeud.UserID(s.newID()),
+		username:      username,
+		email:         email,
+		passwordHash:  passwordHash,
+		created:       now,
+		avatarUpdated: now,
+	}
+	s.users[u.id] = u
+	return u, nil
+}
+
+// ExpireSessions invalidates all sessions, causing subsequent requests to fail with
+// wordfeud.ErrLoginRequired until the users log in again.
+func (s *Server) ExpireSessions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clear(s.sessions)
+}
+
+// StartGame starts a game between two users, with the first user to move. It panics if any of the users
+// does not exist.
+func (s *Server) StartGame(ruleset wordfeud.RulesetID, board wordfeud.BoardID, first, second wordfeud.UserID) wordfeud.GameID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, id := range []wordfeud.UserID{first, second} {
+		if _, ok := s.users[id]; !ok {
+			panic(fmt.Sprintf("wordfeudtest: starting game: unknown user %d", id))
+		}
+	}
+	g, err := s.newGame(ruleset, board, first, second)
+	if err != nil {
+		panic(fmt.Sprintf("wordfeudtest: starting game: %v", err))
+	}
+	return g.id
+}
+
+// SetRack replaces the rack of a player in a game, returning the replaced tiles to the bag. Blank tiles
+// are represented by wordfeud.BlankTile. It panics if the game or player does not exist, or if the
+// tiles are not available in the bag.
+func (s *Server) SetRack(id wordfeud.GameID, player wordfeud.UserID, tiles []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	g, ok := s.games[id]
+	if !ok {
+		panic(fmt.Sprintf("wordfeudtest: setting rack: unknown game %d", id))
+	}
+	p := g.player(player)
+	if p == nil {
+		panic(fmt.Sprintf("wordfeudtest: setting rack: user %d is not playing game %d", player, id))
+	}
+	g.bag = append(g.bag, p.rack...)
+	p.rack = nil
+	for _, t := range tiles {
+		i := slices.Index(g.bag, t)
+		if i < 0 {
+			panic(fmt.Sprintf("wordfeudtest: setting rack: tile %q is not in the bag", t))
+		}
+		g.bag = append(g.bag[:i], g.bag[i+1:]...)
+		p.rack = append(p.rack, t)
+	}
+}
+
+func (s *Server) newID() int64 {
+	id := s.nextID
+	s.nextID++
+	return id
+}
+
+func (s *Server) newSession(u *user) wordfeud.SessionID {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	id := wordfeud.SessionID(hex.EncodeToString(b))
+	s.sessions[id] = u.id
+	return id
+}
+
+// handlerFunc handles an API request made by u, which is nil for requests that do not require a session.
+// It returns the content of the response, or an error which is sent as an API error if it is one of the
+// wordfeud sentinel errors.
+type handlerFunc func(w http.ResponseWriter, r *http.Request, u *user) (any, error)
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+	public := map[string]handlerFunc{
+		"POST /user/create/{$}":      s.createAccount,
+		"POST /user/login/email/{$}": s.loginWithEmail,
+		"POST /user/login/id/{$}":    s.loginWithID,
+		"GET /board/{id}/{$}":        s.board,
+		"GET /ruleset/{id}/{$}":      s.ruleset,
+	}
+	authenticated := map[string]handlerFunc{
+		"POST /user/password/set/{$}":        s.changePassword,
+		"POST /user/avatar/upload/{$}":       s.updateAvatar,
+		"GET /user/relationships/{$}":        s.listRelationships,
+		"POST /relationship/create/{$}":      s.createRelationship,
+		"POST /relationship/{id}/delete/{$}": s.deleteRelationship,
+		"GET /user/games/{$}":                s.listGames,
+		"GET /user/status/{$}":               s.status,
+		"GET /game/{id}/{$}":                 s.game,
+		"POST /invite/new/{$}":               s.invite,
+		"POST /random_request/create/{$}":    s.inviteRandomOpponent,
+		"POST /invite/{id}/accept/{$}":       s.acceptInvitation,
+		"POST /invite/{id}/reject/{$}":       s.rejectInvitation,
+		"POST /game/{id}/move/{$}":           s.move,
+		"POST /game/{id}/pass/{$}":           s.pass,
+		"POST /game/{id}/swap/{$}":           s.swap,
+		"POST /game/{id}/resign/{$}":         s.resign,
+		"GET /user/{id}/chat/{$}":            s.chatMessages,
+		"POST /game/{id}/chat/send/{$}":      s.sendChatMessage,
+	}
+	for pattern, h := range public {
+		mux.Handle(pattern, s.handle(h, false))
+	}
+	for pattern, h := range authenticated {
+		mux.Handle(pattern, s.handle(h, true))
+	}
+	return mux
+}
+
+func (s *Server) handle(h handlerFunc, authenticated bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		var u *user
+		if authenticated {
+			c, err := r.Cookie("sessionid")
+			if err == nil {
+				u = s.users[s.sessions[wordfeud.SessionID(c.Value)]]
+			}
+			if u == nil {
+				writeError(w, wordfeud.ErrLoginRequired)
+				return
+			}
+		}
+
+		content, err := h(w, r, u)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, struct {
+			Status  string `json:"status"`
+			Content any    `json:"content"`
+		}{"success", content})
+	})
+}
+
+// writeError writes err as an API error. Like the real API, errors are sent with status code 200, unless
+// they do not match any of the sentinel errors, in which case they are sent as an HTML Internal Server
+// Error. Any details wrapped around the sentinel error are sent as the error message.
+func writeError(w http.ResponseWriter, err error) {
+	for _, sentinel := range sentinels {
+		if !errors.Is(err, sentinel) {
+			continue
+		}
+		message := strings.TrimPrefix(strings.TrimPrefix(err.Error(), sentinel.Error()), ": ")
+		writeJSON(w, http.StatusOK, struct {
+			Status  string `json:"status"`
+			Content any    `json:"content"`
+		}{"error", struct {
+			Type    string `json:"type"`
+			Message string `json:"message,omitempty"`
+		}{sentinel.Error(), message}})
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	w.WriteHeader(http.StatusInternalServerError)
+	_, _ = fmt.Fprintf(w, "<html><body><h1>Internal Server Error</h1><p>%s</p></body></html>", err)
+}
+
+var sentinels = []error{
+	wordfeud.ErrAccessDenied,
+	wordfeud.ErrAlreadyExists,
+	wordfeud.ErrDuplicateInvite,
+	wordfeud.ErrGameOver,
+	wordfeud.ErrIllegalMove,
+	wordfeud.ErrIllegalTiles,
+	wordfeud.ErrIllegalUserSelf,
+	wordfeud.ErrIllegalWord,
+	wordfeud.ErrInvalidBoardType,
+	wordfeud.ErrInvalidID,
+	wordfeud.ErrInvalidRuleset,
+	wordfeud.ErrLoginRequired,
+	wordfeud.ErrNotFound,
+	wordfeud.ErrNotYourTurn,
+	wordfeud.ErrUnknownEmail,
+	wordfeud.ErrUserNotFound,
+	wordfeud.ErrWrongPassword,
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// The real API sends JSON as text/plain.
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(status)
+	_, _ = w.Write(b)
+}
+
+// decode unmarshals the JSON request body into v.
+func decode(r *http.Request, v any) error {
+	err := json.NewDecoder(r.Body).Decode(v)
+	if err != nil {
+		return fmt.Errorf("decoding request body: %v", err)
+	}
+	return nil
+}
+
+// pathID parses the {id} wildcard of the request path.
+func pathID(r *http.Request) (int64, error) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		return 0, wordfeud.ErrInvalidID
+	}
+	return id, nil
+}
@@ -0,0 +1,220 @@
+package wordfeudtest_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/kayex/wordfeud"
+	"github.com/kayex/wordfeud/wordfeudtest"
+)
+
+// setup starts a server with two users playing a game against each other, and logs them in.
+func setup(t *testing.T) (server *wordfeudtest.Server, client *wordfeud.Client, game wordfeud.GameID, alice, bob wordfeud.SessionID) {
+	t.Helper()
+	server = wordfeudtest.NewServer(wordfeudtest.WithSeed(1))
+	t.Cleanup(server.Close)
+
+	a := server.AddUser("alice", "alice@example.com", "alice password")
+	b := server.AddUser("bob", "bob@example.com", "bob password")
+	game = server.StartGame(wordfeud.RuleSetEnglish, wordfeud.BoardNormal, a, b)
+	server.SetRack(game, a, []string{"H", "E", "L", "L", "O", "A", "B"})
+
+	client = server.Client()
+	ctx := context.Background()
+	var err error
+	alice, err = client.LoginWithEmail(ctx, "alice@example.com", "alice password")
+	if err != nil {
+		t.Fatalf("logging in alice: %v", err)
+	}
+	bob, err = client.LoginWithID(ctx, b, "bob password")
+	if err != nil {
+		t.Fatalf("logging in bob: %v", err)
+	}
+	return server, client, game, alice, bob
+}
+
+func TestLogin(t *testing.T) {
+	server := wordfeudtest.NewServer()
+	defer server.Close()
+	server.AddUser("alice", "alice@example.com", "password")
+	client := server.Client()
+	ctx := context.Background()
+
+	session, err := client.LoginWithEmail(ctx, "alice@example.com", "password")
+	if err != nil {
+		t.Fatalf("LoginWithEmail returned error: %v", err)
+	}
+	if session == "" {
+		t.Error("LoginWithEmail returned an empty session")
+	}
+	_, err = client.Games(ctx, session)
+	if err != nil {
+		t.Errorf("Games returned error for a new session: %v", err)
+	}
+
+	_, err = client.LoginWithEmail(ctx, "alice@example.com", "wrong")
+	if !errors.Is(err, wordfeud.ErrWrongPassword) {
+		t.Errorf("got error %v for wrong password, want ErrWrongPassword", err)
+	}
+	_, err = client.LoginWithEmail(ctx, "nobody@example.com", "password")
+	if !errors.Is(err, wordfeud.ErrUnknownEmail) {
+		t.Errorf("got error %v for unknown email, want ErrUnknownEmail", err)
+	}
+}
+
+func TestLoginRequired(t *testing.T) {
+	server, client, _, alice, _ := setup(t)
+	ctx := context.Background()
+
+	_, err := client.Games(ctx, "")
+	if !errors.Is(err, wordfeud.ErrLoginRequired) {
+		t.Errorf("got error %v without session, want ErrLoginRequired", err)
+	}
+
+	server.ExpireSessions()
+	_, err = client.Status(ctx, alice)
+	if !errors.Is(err, wordfeud.ErrLoginRequired) {
+		t.Fatalf("got error %v for expired session, want ErrLoginRequired", err)
+	}
+	var apiErr *wordfeud.APIError
+	if !errors.As(err, &apiErr) || apiErr.Type != "login_required" {
+		t.Errorf("got error %#v, want an APIError of type login_required", err)
+	}
+
+	s := wordfeud.NewSession(client, wordfeud.Credentials{Email: "alice@example.com", Password: "alice password"})
+	_, err = s.Status(ctx)
+	if err != nil {
+		t.Errorf("Session.Status returned error after logging in again: %v", err)
+	}
+}
+
+func TestMove(t *testing.T) {
+	_, client, game, alice, bob := setup(t)
+	ctx := context.Background()
+
+	move := []wordfeud.Placement{
+		wordfeud.Place(5, 7, "H", false),
+		wordfeud.Place(6, 7, "E", false),
+		wordfeud.Place(7, 7, "L", false),
+		wordfeud.Place(8, 7, "L", false),
+		wordfeud.Place(9, 7, "O", false),
+	}
+	res, err := client.Move(ctx, alice, game, move)
+	if err != nil {
+		t.Fatalf("Move returned error: %v", err)
+	}
+	if res.Points == nil || *res.Points != 8 {
+		t.Errorf("got points %v, want 8", res.Points)
+	}
+	if res.MainWord == nil || *res.MainWord != "HELLO" {
+		t.Errorf("got main word %v, want HELLO", res.MainWord)
+	}
+	if len(res.NewTiles) != len(move) {
+		t.Errorf("got %d new tiles, want %d", len(res.NewTiles), len(move))
+	}
+
+	_, err = client.Move(ctx, alice, game, []wordfeud.Placement{wordfeud.Place(10, 7, "A", false)})
+	if !errors.Is(err, wordfeud.ErrNotYourTurn) {
+		t.Errorf("got error %v when moving twice, want ErrNotYourTurn", err)
+	}
+	_, err = client.Move(ctx, bob, game, []wordfeud.Placement{wordfeud.Place(0, 0, "A", false)})
+	if !errors.Is(err, wordfeud.ErrIllegalMove) {
+		t.Errorf("got error %v for disconnected tile, want ErrIllegalMove", err)
+	}
+
+	g, err := client.Game(ctx, bob, game)
+	if err != nil {
+		t.Fatalf("Game returned error: %v", err)
+	}
+	if g.MoveCount != 1 {
+		t.Errorf("got move count %d, want 1", g.MoveCount)
+	}
+	if !slices.Equal(g.Tiles, move) {
+		t.Errorf("got tiles %v, want %v", g.Tiles, move)
+	}
+	if g.LastMove == nil || g.LastMove.MoveType != wordfeud.MoveTypeMove || !slices.Equal(g.LastMove.Move, move) {
+		t.Errorf("got last move %+v, want the move just made", g.LastMove)
+	}
+	for _, p := range g.Players {
+		if p.Username == "alice" && p.Score != 8 {
+			t.Errorf("alice has score %d, want 8", p.Score)
+		}
+		if p.Username == "bob" && len(p.Rack) != wordfeud.RackSize {
+			t.Errorf("bob has %d tiles on the rack, want %d", len(p.Rack), wordfeud.RackSize)
+		}
+	}
+}
+
+func TestSwap(t *testing.T) {
+	_, client, game, alice, bob := setup(t)
+	ctx := context.Background()
+
+	_, err := client.Swap(ctx, alice, game, []string{"Q"})
+	if !errors.Is(err, wordfeud.ErrIllegalTiles) {
+		t.Errorf("got error %v swapping a tile not on the rack, want ErrIllegalTiles", err)
+	}
+
+	res, err := client.Swap(ctx, alice, game, []string{"A", "B"})
+	if err != nil {
+		t.Fatalf("Swap returned error: %v", err)
+	}
+	if len(res.NewTiles) != 2 {
+		t.Errorf("got %d new tiles, want 2", len(res.NewTiles))
+	}
+
+	g, err := client.Game(ctx, bob, game)
+	if err != nil {
+		t.Fatalf("Game returned error: %v", err)
+	}
+	m := g.LastMove
+	if m == nil || m.MoveType != wordfeud.MoveTypeSwap || m.TileCount == nil || *m.TileCount != 2 {
+		t.Errorf("got last move %+v, want a swap of 2 tiles", m)
+	}
+	if len(g.Tiles) != 0 {
+		t.Errorf("got %d tiles on the board after a swap, want 0", len(g.Tiles))
+	}
+}
+
+func TestStatusAndChat(t *testing.T) {
+	_, client, game, alice, bob := setup(t)
+	ctx := context.Background()
+
+	sent, err := client.SendChatMessage(ctx, alice, game, "Good luck!")
+	if err != nil {
+		t.Fatalf("SendChatMessage returned error: %v", err)
+	}
+
+	status, err := client.Status(ctx, bob)
+	if err != nil {
+		t.Fatalf("Status returned error: %v", err)
+	}
+	if len(status.Games) != 1 {
+		t.Fatalf("got %d games in status, want 1", len(status.Games))
+	}
+	gs := status.Games[0]
+	if gs.ID != game || gs.ChatCount != 1 || gs.ReadChatCount != 0 {
+		t.Errorf("got game status %+v, want game %d with 1 unread message", gs, game)
+	}
+
+	messages, err := client.ChatMessages(ctx, bob, game)
+	if err != nil {
+		t.Fatalf("ChatMessages returned error: %v", err)
+	}
+	if len(messages) != 1 {
+		t.Fatalf("got %d messages, want 1", len(messages))
+	}
+	msg := messages[0]
+	if msg.Message != "Good luck!" || !msg.Sent.Equal(sent.Time) {
+		t.Errorf("got message %+v, want %q sent at %v", msg, "Good luck!", sent)
+	}
+
+	status, err = client.Status(ctx, bob)
+	if err != nil {
+		t.Fatalf("Status returned error: %v", err)
+	}
+	if gs := status.Games[0]; gs.ReadChatCount != 1 {
+		t.Errorf("got %d read messages after reading the chat, want 1", gs.ReadChatCount)
+	}
+}